@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"unix_sort_lite/internal/domain"
 	"unix_sort_lite/internal/usecase"
 
@@ -12,10 +13,73 @@ import (
 
 func main() {
 	// flags init
-	field := pflag.IntP("key", "k", 0, "number of field")
+	keys := pflag.StringArrayP("key", "k", nil, "sort by field N[:type], JSON pointer/path with --jsonl or key name with --logfmt (repeatable)")
+	keyRegex := pflag.StringArray("key-regex", nil, "take key from the first capture group of RE (repeatable)")
+	regexMissing := pflag.String("regex-missing", "last", "place lines not matching --key-regex first or last, or fail with error")
 	numeric := pflag.BoolP("numeric", "n", false, "numeric sort")
 	month := pflag.BoolP("month-sort", "M", false, "month sort")
+	weekday := pflag.Bool("weekday-sort", false, "weekday sort")
+	monthLocale := pflag.String("month-locale", "", "locale of month and weekday names (default from LC_TIME)")
 	humanNumeric := pflag.BoolP("human-numeric-sort", "h", false, "human numeric sort")
+	duration := pflag.Bool("duration-sort", false, "sort by durations like 12ms, 1h30m, 2d")
+	date := pflag.Bool("date-sort", false, "date sort (RFC 3339/ISO 8601 by default)")
+	logTime := pflag.Bool("log-time", false, "sort log lines by detected timestamp")
+	ip := pflag.Bool("ip-sort", false, "sort by IPv4/IPv6 address or CIDR prefix")
+	domainSort := pflag.Bool("domain-sort", false, "sort host names by labels from TLD")
+	email := pflag.Bool("email-sort", false, "sort e-mail addresses by domain, then local part")
+	url := pflag.Bool("url-sort", false, "sort URLs by host, path and query")
+	path := pflag.Bool("path-sort", false, "sort file paths component by component")
+	dirsFirst := pflag.Bool("dirs-first", false, "with --path-sort, list directories before files")
+	pathVersion := pflag.Bool("path-version", false, "with --path-sort, compare components as versions")
+	statKey := pflag.String("stat-key", "", "sort paths by file size, mtime, atime, ctime, mode or owner")
+	statMissing := pflag.String("stat-missing", "last", "place missing files first or last, or fail with error")
+	metric := pflag.String("metric", "", "sort by line metric: bytes, runes, width or words")
+	tiebreak := pflag.String("tiebreak-metric", "", "with -k, order equal keys by line metric")
+	stripANSI := pflag.Bool("strip-ansi", false, "ignore ANSI escape sequences (colors) in keys")
+	var keyTransforms []string
+	transformVar(&keyTransforms, "key-sub", "sub", "string", "transform all keys (every -k, --order-by and --key-regex key) with sed-style s/RE/REPL/[gi] (repeatable)")
+	transformVar(&keyTransforms, "key-trim", "trim", "", "trim blanks around all keys")
+	transformVar(&keyTransforms, "key-squeeze", "squeeze", "", "collapse runs of blanks in all keys to one space")
+	transformVar(&keyTransforms, "key-lower", "lower", "", "lowercase all keys")
+	transformVar(&keyTransforms, "key-prefix", "prefix", "int", "compare only the first N characters of all keys")
+	transformVar(&keyTransforms, "key-suffix", "suffix", "int", "compare only the last N characters of all keys")
+	transformVar(&keyTransforms, "key-reverse", "reverse", "", "reverse characters of all keys")
+	normalize := pflag.String("normalize", "", "normalize keys to Unicode form NFC, NFD, NFKC or NFKD")
+	ignoreAccents := pflag.Bool("ignore-accents", false, "ignore diacritics when comparing keys")
+	phonetic := pflag.String("phonetic", "", "compare keys by sound: soundex, metaphone or cyrillic")
+	near := pflag.String("near", "", "sort by great-circle distance from LAT,LON")
+	geoFields := pflag.IntSlice("geo-fields", nil, "fields with latitude and longitude (LAT,LON) or one \"lat,lon\" field")
+	order := pflag.StringSlice("order", nil, "sort by position in comma-separated LIST")
+	orderFile := pflag.String("order-file", "", "sort by position in FILE, one value per line")
+	orderIgnoreCase := pflag.Bool("order-ignore-case", false, "match --order values case-insensitively")
+	orderUnknown := pflag.String("order-unknown", "last", "place values missing from --order first or last")
+	locale := pflag.String("locale", "", "collation locale, C or POSIX for byte order (default from LC_COLLATE)")
+	caseFirst := pflag.String("case-first", "", "order of letters differing only in case: upper or lower")
+	csv := pflag.Bool("csv", false, "parse input as RFC 4180 CSV records, -k selects a cell")
+	csvDelimiter := pflag.String("csv-delimiter", ",", "CSV cell delimiter (\\t for tab)")
+	csvQuote := pflag.String("csv-quote", "\"", "CSV quote character")
+	lazyQuotes := pflag.Bool("lazy-quotes", false, "allow bare and unescaped quotes in CSV cells")
+	jsonl := pflag.Bool("jsonl", false, "parse input as JSON Lines, -k takes JSON pointers or paths")
+	jsonNull := pflag.String("json-null", "last", "with --jsonl, place null and missing values first or last")
+	paragraph := pflag.Bool("paragraph", false, "sort blank-line separated paragraphs as whole records")
+	recordStart := pflag.String("record-start", "", "sort multi-line records starting with lines matching REGEX")
+	keyLine := pflag.Int("key-line", 1, "with --paragraph or --record-start, take keys from line N of each record")
+	table := pflag.Bool("table", false, "sort body rows of Markdown and ASCII tables, keeping headers and formatting")
+	logfmt := pflag.Bool("logfmt", false, "parse input as logfmt, -k takes key names")
+	logfmtMissing := pflag.String("logfmt-missing", "last", "with --logfmt, place lines without the key first or last")
+	jsonDoc := pflag.Bool("json", false, "parse input as one JSON document and sort arrays inside it")
+	jsonArrays := pflag.StringArray("json-array", nil, "with --json, sort arrays at JSONPath like $.dependencies[*] (repeatable)")
+	jsonArrayKey := pflag.String("json-array-key", "", "with --json, compare array elements by pointer or path[:type] (default whole element)")
+	sortObjectKeys := pflag.Bool("sort-object-keys", false, "with --json, sort object keys recursively")
+	header := pflag.Int("header", 0, "keep the first N lines (CSV records with --csv) in place")
+	orderBy := pflag.String("order-by", "", "with --header or --table, sort by \"col[:type] [ASC|DESC] [NULLS FIRST|LAST], ...\"")
+	columns := pflag.String("columns", "", "fixed-width fields by character ranges like 1-10,11-25,26-")
+	autoColumns := pflag.Bool("auto-columns", false, "infer fixed-width fields from the header line (implies --header 1)")
+	sectionRegex := pflag.String("section-regex", "", "keep lines matching REGEX as section headers, sort each section separately")
+	sections := pflag.Bool("sections", false, "like --section-regex for INI [headers] and #/; comment lines")
+	separator := pflag.StringP("field-separator", "t", "", "use SEP instead of blanks as field separator")
+	dateFormats := pflag.StringArray("date-format", nil, "Go time layout for --date-sort (repeatable)")
+	timezone := pflag.String("timezone", "", "time zone for dates without offset (default UTC)")
 	reverse := pflag.BoolP("reverse", "r", false, "reverse")
 	blanks := pflag.BoolP("ignore-trailing-blanks", "b", false, "ignore blanks")
 	unique := pflag.BoolP("unique", "u", false, "unique")
@@ -24,20 +88,90 @@ func main() {
 	pflag.Parse()
 
 	opts := domain.SortOptions{
-		Field:        *field,
-		Numeric:      *numeric,
-		Month:        *month,
-		HumanNumeric: *humanNumeric,
-		Reverse:      *reverse,
-		IgnoreBlanks: *blanks,
-		Unique:       *unique,
-		Check:        *check,
+		KeySpecs:        *keys,
+		KeyRegex:        *keyRegex,
+		Numeric:         *numeric,
+		Month:           *month,
+		Weekday:         *weekday,
+		HumanNumeric:    *humanNumeric,
+		Date:            *date,
+		Duration:        *duration,
+		LogTime:         *logTime,
+		Separator:       *separator,
+		Columns:         *columns,
+		AutoColumns:     *autoColumns,
+		DateFormats:     *dateFormats,
+		Timezone:        *timezone,
+		MonthLocale:     *monthLocale,
+		IP:              *ip,
+		Domain:          *domainSort,
+		Email:           *email,
+		URL:             *url,
+		Path:            *path,
+		StatKey:         *statKey,
+		Metric:          *metric,
+		Near:            *near,
+		Order:           *order,
+		OrderIgnoreCase: *orderIgnoreCase,
+		OrderUnknown:    *orderUnknown,
+		DirsFirst:       *dirsFirst,
+		PathVersion:     *pathVersion,
+		StatMissing:     *statMissing,
+		RegexMissing:    *regexMissing,
+		CSV:             *csv,
+		CSVDelimiter:    *csvDelimiter,
+		CSVQuote:        *csvQuote,
+		LazyQuotes:      *lazyQuotes,
+		Header:          *header,
+		SectionRegex:    *sectionRegex,
+		Sections:        *sections,
+		JSONL:           *jsonl,
+		JSONNull:        *jsonNull,
+		Paragraph:       *paragraph,
+		RecordStart:     *recordStart,
+		KeyLine:         *keyLine,
+		Table:           *table,
+		Logfmt:          *logfmt,
+		LogfmtMissing:   *logfmtMissing,
+		JSON:            *jsonDoc,
+		JSONArrays:      *jsonArrays,
+		JSONArrayKey:    *jsonArrayKey,
+		SortObjectKeys:  *sortObjectKeys,
+		OrderBy:         *orderBy,
+		GeoFields:       *geoFields,
+		Locale:          *locale,
+		CaseFirst:       *caseFirst,
+		Tiebreak:        *tiebreak,
+		StripANSI:       *stripANSI,
+		KeyTransforms:   keyTransforms,
+		Normalize:       *normalize,
+		IgnoreAccents:   *ignoreAccents,
+		Phonetic:        *phonetic,
+		Reverse:         *reverse,
+		IgnoreBlanks:    *blanks,
+		Unique:          *unique,
+		Check:           *check,
 	}
-	pflag.Visit(func(f *pflag.Flag) {
-		if f.Name == "key" {
-			opts.Key = true
+	if opts.MonthLocale == "" {
+		// Локаль названий месяцев по правилам POSIX: LC_ALL > LC_TIME > LANG
+		opts.MonthLocale = envLocale("LC_TIME")
+	}
+	if opts.Locale == "" {
+		// Локаль сравнения строк по правилам POSIX: LC_ALL > LC_COLLATE > LANG
+		opts.Locale, opts.LocaleFromEnv = envLocale("LC_COLLATE"), true
+	}
+	if *orderFile != "" {
+		values, err := readOrderFile(*orderFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
 		}
-	})
+		opts.Order = append(opts.Order, values...)
+	}
+	if (pflag.CommandLine.Changed("order") || *orderFile != "") && len(opts.Order) == 0 {
+		fmt.Fprintln(os.Stderr, "Error:", domain.ErrEmptyOrder)
+		os.Exit(1)
+	}
 
 	args := pflag.Args()
 
@@ -83,3 +217,66 @@ func main() {
 
 	fmt.Println(result)
 }
+
+// transformFlag — значение флага преобразования ключа. Все флаги --key-* пишут
+// в общий список, поэтому преобразования применяются в порядке командной строки.
+type transformFlag struct {
+	name    string
+	argType string // тип аргумента для справки, пустой для флагов без аргумента
+	chain   *[]string
+}
+
+func (f *transformFlag) String() string { return "" }
+
+func (f *transformFlag) Type() string {
+	if f.argType == "" {
+		return "bool"
+	}
+	return f.argType
+}
+
+func (f *transformFlag) Set(value string) error {
+	switch {
+	case f.argType != "":
+		*f.chain = append(*f.chain, f.name+"="+value)
+	case value == "true":
+		*f.chain = append(*f.chain, f.name)
+	}
+	return nil
+}
+
+// transformVar регистрирует флаг преобразования ключа name с записью в chain.
+func transformVar(chain *[]string, flagName, name, argType, usage string) {
+	flag := pflag.CommandLine.VarPF(&transformFlag{name: name, argType: argType, chain: chain}, flagName, "", usage)
+	if argType == "" {
+		flag.NoOptDefVal = "true"
+	}
+}
+
+// envLocale возвращает локаль категории category из окружения с приоритетом
+// LC_ALL > category > LANG.
+func envLocale(category string) string {
+	for _, name := range []string{"LC_ALL", category, "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// readOrderFile читает пользовательский порядок значений: одно значение на строку,
+// пустые строки пропускаются.
+func readOrderFile(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			values = append(values, line)
+		}
+	}
+	return values, nil
+}
@@ -5,6 +5,7 @@ go 1.24.2
 require (
 	github.com/spf13/pflag v1.0.10
 	github.com/stretchr/testify v1.11.1
+	golang.org/x/text v0.31.0
 )
 
 require (
@@ -3,7 +3,36 @@ package domain
 import "errors"
 
 var (
-	ErrConflictOpts  = errors.New("sort: conflicting sort options")
-	ErrWrongOrder    = errors.New("sort: wrong order")
-	ErrInvalideField = errors.New("sort: invalid number of field")
+	ErrConflictOpts         = errors.New("sort: conflicting sort options")
+	ErrWrongOrder           = errors.New("sort: wrong order")
+	ErrInvalideField        = errors.New("sort: invalid number of field")
+	ErrInvalidTimezone      = errors.New("sort: invalid time zone")
+	ErrInvalidPlace         = errors.New("sort: invalid placement, expected first or last")
+	ErrEmptyOrder           = errors.New("sort: empty custom order list")
+	ErrInvalidStatKey       = errors.New("sort: invalid stat key, expected size, mtime, atime, ctime, mode or owner")
+	ErrMissingFile          = errors.New("sort: cannot stat file")
+	ErrInvalidMetric        = errors.New("sort: invalid metric, expected bytes, runes, width or words")
+	ErrInvalidCoordinates   = errors.New("sort: invalid coordinates, expected LAT,LON in decimal degrees")
+	ErrInvalidLocale        = errors.New("sort: invalid locale")
+	ErrInvalidCaseFirst     = errors.New("sort: invalid case order, expected upper or lower")
+	ErrInvalidNormalization = errors.New("sort: invalid normalization form, expected NFC, NFD, NFKC or NFKD")
+	ErrInvalidTransform     = errors.New("sort: invalid key transform")
+	ErrInvalidRegex         = errors.New("sort: invalid key regex")
+	ErrNoRegexMatch         = errors.New("sort: line does not match key regex")
+	ErrInvalidCSV           = errors.New("sort: invalid CSV")
+	ErrInvalidCSVDialect    = errors.New("sort: invalid CSV delimiter or quote, expected distinct single characters")
+	ErrInvalidHeader        = errors.New("sort: invalid number of header lines")
+	ErrOrderByHeader        = errors.New("sort: --order-by requires --header")
+	ErrInvalidOrderBy       = errors.New("sort: invalid --order-by")
+	ErrUnknownColumn        = errors.New("sort: unknown column")
+	ErrInvalidKeyType       = errors.New("sort: invalid key type")
+	ErrInvalidJSONPath      = errors.New("sort: invalid JSON pointer or path")
+	ErrInvalidJSON          = errors.New("sort: invalid JSON document")
+	ErrJSONDocument         = errors.New("sort: --json-array and --sort-object-keys require --json")
+	ErrInvalidLogfmtKey     = errors.New("sort: invalid logfmt key name")
+	ErrInvalidColumns       = errors.New("sort: invalid column range, expected N, N-M or N-")
+	ErrInvalidRecordStart   = errors.New("sort: invalid --record-start regex")
+	ErrInvalidKeyLine       = errors.New("sort: invalid --key-line, expected line number from 1")
+	ErrInvalidSectionRegex  = errors.New("sort: invalid --section-regex")
+	ErrInvalidPhonetic      = errors.New("sort: invalid phonetic scheme, expected soundex, metaphone or cyrillic")
 )
@@ -2,17 +2,94 @@ package domain
 
 type SortOptions struct {
 	// Тип сортировки
-	Field        int  // N
-	Key          bool // flag -k N
-	Numeric      bool // flag -n
-	Month        bool // flag -M
-	HumanNumeric bool // falg -h
+	Field        int       // N
+	Key          bool      // flag -k N
+	KeyRegex     []string  // flag --key-regex RE
+	Numeric      bool      // flag -n
+	Month        bool      // flag -M
+	Weekday      bool      // flag --weekday-sort
+	HumanNumeric bool      // falg -h
+	Date         bool      // flag --date-sort
+	Duration     bool      // flag --duration-sort
+	LogTime      bool      // flag --log-time
+	Order        []string  // flag --order LIST или --order-file FILE
+	IP           bool      // flag --ip-sort
+	Domain       bool      // flag --domain-sort
+	Email        bool      // flag --email-sort
+	URL          bool      // flag --url-sort
+	Path         bool      // flag --path-sort
+	StatKey      string    // flag --stat-key size|mtime|atime|ctime|mode|owner
+	Metric       string    // flag --metric bytes|runes|width|words
+	Near         string    // flag --near LAT,LON
+	KeySpecs     []string  // flag -k N[:type], -k PATH[:type] с --jsonl или -k NAME[:type] с --logfmt (повторяемый)
+	Keys         []SortKey // flags -k и --order-by, колонки разрешаются по заголовку
+
+	// Параметры разбора
+	Separator       string   // flag -t
+	Columns         string   // flag --columns "1-10,11-25,26-"
+	AutoColumns     bool     // flag --auto-columns
+	DateFormats     []string // flag --date-format
+	Timezone        string   // flag --timezone
+	MonthLocale     string   // flag --month-locale или LC_TIME
+	OrderIgnoreCase bool     // flag --order-ignore-case
+	OrderUnknown    string   // flag --order-unknown first|last
+	DirsFirst       bool     // flag --dirs-first
+	PathVersion     bool     // flag --path-version
+	StatMissing     string   // flag --stat-missing first|last|error
+	RegexMissing    string   // flag --regex-missing first|last|error
+	CSV             bool     // flag --csv
+	CSVDelimiter    string   // flag --csv-delimiter
+	CSVQuote        string   // flag --csv-quote
+	LazyQuotes      bool     // flag --lazy-quotes
+	Header          int      // flag --header N
+	SectionRegex    string   // flag --section-regex REGEX
+	Sections        bool     // flag --sections (заголовки INI и комментарии)
+	JSONL           bool     // flag --jsonl
+	JSONNull        string   // flag --json-null first|last
+	Paragraph       bool     // flag --paragraph
+	RecordStart     string   // flag --record-start REGEX
+	KeyLine         int      // flag --key-line N
+	Table           bool     // flag --table
+	Logfmt          bool     // flag --logfmt
+	LogfmtMissing   string   // flag --logfmt-missing first|last
+	JSON            bool     // flag --json
+	JSONArrays      []string // flag --json-array PATH (повторяемый)
+	JSONArrayKey    string   // flag --json-array-key PATH[:type]
+	SortObjectKeys  bool     // flag --sort-object-keys
+	OrderBy         string   // flag --order-by "col[:type] [ASC|DESC] [NULLS FIRST|LAST], ..." с --header или --table
+	GeoFields       []int    // flag --geo-fields LAT[,LON]
+	Locale          string   // flag --locale или LC_COLLATE
+	LocaleFromEnv   bool     // Locale взята из окружения: неизвестная локаль означает побайтовое сравнение
+	CaseFirst       string   // flag --case-first upper|lower
 
 	// Модификаторы
-	Reverse      bool // flag -r
-	IgnoreBlanks bool // flag -b
-	Unique       bool // flag -u
+	Tiebreak      string   // flag --tiebreak-metric bytes|runes|width|words
+	StripANSI     bool     // flag --strip-ansi
+	KeyTransforms []string // flags --key-sub, --key-trim, --key-squeeze, --key-lower, --key-prefix, --key-suffix, --key-reverse
+	Normalize     string   // flag --normalize NFC|NFD|NFKC|NFKD
+	IgnoreAccents bool     // flag --ignore-accents
+	Phonetic      string   // flag --phonetic soundex|metaphone|cyrillic
+	Reverse       bool     // flag -r
+	IgnoreBlanks  bool     // flag -b
+	Unique        bool     // flag -u
 
 	// Анализ
 	Check bool // flag -c
 }
+
+// Размещение значений, которые не удалось распознать (неизвестных, отсутствующих)
+const (
+	PlaceFirst = "first"
+	PlaceLast  = "last"
+	PlaceError = "error" // не размещать, а завершиться с ошибкой
+)
+
+// SortKey описывает один ключ многоключевой сортировки: поле, тип сравнения,
+// направление и размещение пустых значений.
+type SortKey struct {
+	Field int    // номер поля, с 1
+	Path  string // путь к значению JSON (--jsonl): "/a/b" или ".a.b"; имя ключа (--logfmt)
+	Type  string // тип сравнения: n, h, M, date, ip, ...; пустой — текст
+	Desc  bool   // по убыванию
+	Nulls string // PlaceFirst или PlaceLast; пустое — last для ASC, first для DESC
+}
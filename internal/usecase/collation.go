@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unix_sort_lite/internal/domain"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// Порядок регистра для флага --case-first
+const (
+	caseUpper = "upper" // "Apple" перед "apple"
+	caseLower = "lower" // "apple" перед "Apple"
+)
+
+// collation сравнивает строки по правилам локали (Unicode Collation Algorithm, таблицы CLDR).
+// Нулевой указатель означает побайтовое сравнение, как в локалях C и POSIX.
+type collation struct {
+	full       *collate.Collator
+	ignoreCase *collate.Collator
+	caseFirst  string
+}
+
+// newCollation строит правила сравнения для локали --locale (или LC_ALL/LC_COLLATE/LANG).
+// Для пустой локали, C и POSIX возвращается nil. Флаг --case-first без локали
+// включает корневую таблицу CLDR, чтобы порядок регистра имел смысл.
+// Неизвестная локаль из окружения, как в GNU sort, равносильна локали C;
+// ошибку domain.ErrInvalidLocale дает только явный --locale.
+func newCollation(opts domain.SortOptions) (*collation, error) {
+	switch opts.CaseFirst {
+	case "", caseUpper, caseLower:
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidCaseFirst, opts.CaseFirst)
+	}
+
+	tag := language.Und
+	if !isByteLocale(opts.Locale) {
+		var err error
+		if tag, err = language.Parse(localeTag(opts.Locale)); err != nil {
+			if !opts.LocaleFromEnv {
+				return nil, fmt.Errorf("%w: %q", domain.ErrInvalidLocale, opts.Locale)
+			}
+			return newCollation(domain.SortOptions{CaseFirst: opts.CaseFirst})
+		}
+	} else if opts.CaseFirst == "" {
+		return nil, nil
+	}
+
+	return &collation{
+		full:       collate.New(tag),
+		ignoreCase: collate.New(tag, collate.IgnoreCase),
+		caseFirst:  opts.CaseFirst,
+	}, nil
+}
+
+// isByteLocale сообщает, сравниваются ли строки в локали побайтово: "", "C", "POSIX", "C.UTF-8".
+func isByteLocale(locale string) bool {
+	switch localeLanguage(locale) {
+	case "", "c", "posix":
+		return true
+	default:
+		return false
+	}
+}
+
+// localeTag переводит имя локали POSIX в тег BCP 47: "ru_RU.UTF-8" → "ru-RU",
+// "sr_RS@latin" → "sr-RS". Теги BCP 47 ("de-DE") возвращаются без изменений.
+func localeTag(locale string) string {
+	if end := strings.IndexAny(locale, ".@"); end >= 0 {
+		locale = locale[:end]
+	}
+	return strings.ReplaceAll(locale, "_", "-")
+}
+
+// less сравнивает строки по правилам локали. Строки, равные по правилам локали,
+// но различающиеся побайтово, упорядочиваются побайтово, чтобы порядок был полным.
+// Для nil сравнение побайтовое.
+//
+// Примеры порядка (локаль ru_RU.UTF-8):
+//
+//	"еж" < "Еж" < "ёж" < "Ёж" < "жук" < "Яблоко"
+//	"Ärger" < "apple" < "Zebra" (en_US.UTF-8, без --case-first)
+func (c *collation) less(iStr, jStr string) bool {
+	if c == nil {
+		return iStr < jStr
+	}
+
+	if c.caseFirst != "" {
+		if cmp := c.ignoreCase.CompareString(iStr, jStr); cmp != 0 {
+			return cmp < 0
+		}
+		if cmp := compareCase(iStr, jStr, c.caseFirst); cmp != 0 {
+			return cmp < 0
+		}
+	}
+	if cmp := c.full.CompareString(iStr, jStr); cmp != 0 {
+		return cmp < 0
+	}
+	return iStr < jStr
+}
+
+// compareCase сравнивает строки, равные без учета регистра, по первой букве,
+// различающейся только регистром: с caseUpper заглавная идет первой, с caseLower — строчная.
+func compareCase(iStr, jStr, caseFirst string) int {
+	iRunes, jRunes := []rune(iStr), []rune(jStr)
+	for k := 0; k < len(iRunes) && k < len(jRunes); k++ {
+		iUpper, jUpper := unicode.IsUpper(iRunes[k]), unicode.IsUpper(jRunes[k])
+		if iUpper == jUpper {
+			continue
+		}
+		if iUpper == (caseFirst == caseUpper) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
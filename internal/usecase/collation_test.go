@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortDefaultLocale(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "C locale keeps byte order",
+			input:    "Яблоко\nЁлка\nZebra\napple",
+			opts:     domain.SortOptions{Locale: "C"},
+			expected: "Zebra\napple\nЁлка\nЯблоко",
+		},
+		{
+			name:     "C.UTF-8 keeps byte order",
+			input:    "b\nB\na",
+			opts:     domain.SortOptions{Locale: "C.UTF-8"},
+			expected: "B\na\nb",
+		},
+		{
+			name:     "russian yo after ye",
+			input:    "Яблоко\nЁж\nЕж\nЖук",
+			opts:     domain.SortOptions{Locale: "ru_RU.UTF-8"},
+			expected: "Еж\nЁж\nЖук\nЯблоко",
+		},
+		{
+			name:     "english case interleaves",
+			input:    "Zebra\napple\nBanana",
+			opts:     domain.SortOptions{Locale: "en_US.UTF-8"},
+			expected: "apple\nBanana\nZebra",
+		},
+		{
+			name:     "accented letters next to base letters",
+			input:    "zoo\nÄpfel\napple\nbanana",
+			opts:     domain.SortOptions{Locale: "de-DE"},
+			expected: "Äpfel\napple\nbanana\nzoo",
+		},
+		{
+			name:     "lower case first by default",
+			input:    "Ель\nель",
+			opts:     domain.SortOptions{Locale: "ru_RU"},
+			expected: "ель\nЕль",
+		},
+		{
+			name:     "upper case first",
+			input:    "ёж\nЁж\nеж\nЕж",
+			opts:     domain.SortOptions{Locale: "ru_RU", CaseFirst: "upper"},
+			expected: "Еж\nеж\nЁж\nёж",
+		},
+		{
+			name:     "case first without locale uses root collation",
+			input:    "b\nB\na\nA",
+			opts:     domain.SortOptions{CaseFirst: "upper"},
+			expected: "A\na\nB\nb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortDefault(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSortByFieldLocale(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	opts := domain.SortOptions{Key: true, Field: 2, Locale: "ru_RU.UTF-8"}
+	result := SortByField("1 Яблоко\n2 Ёж\n3 еж", identity, opts)
+	require.Equal(t, "3 еж\n2 Ёж\n1 Яблоко", result)
+}
+
+func TestNewCollation(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    domain.SortOptions
+		wantNil bool
+		wantErr error
+	}{
+		{name: "no locale", opts: domain.SortOptions{}, wantNil: true},
+		{name: "POSIX", opts: domain.SortOptions{Locale: "POSIX"}, wantNil: true},
+		{name: "posix name", opts: domain.SortOptions{Locale: "sr_RS@latin"}},
+		{name: "bcp 47 tag", opts: domain.SortOptions{Locale: "de-DE"}},
+		{name: "invalid locale", opts: domain.SortOptions{Locale: "not a locale"}, wantErr: domain.ErrInvalidLocale},
+		{name: "invalid env locale", opts: domain.SortOptions{Locale: "xx_YY.UTF-8", LocaleFromEnv: true}, wantNil: true},
+		{name: "invalid env locale with case first", opts: domain.SortOptions{Locale: "xx_YY.UTF-8", LocaleFromEnv: true, CaseFirst: caseUpper}},
+		{name: "invalid case first", opts: domain.SortOptions{CaseFirst: "title"}, wantErr: domain.ErrInvalidCaseFirst},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			coll, err := newCollation(tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantNil, coll == nil)
+		})
+	}
+}
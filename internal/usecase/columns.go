@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// columnRange — колонка фиксированной ширины: позиции символов с 1, включительно.
+// Нулевой end означает колонку до конца строки.
+type columnRange struct {
+	start, end int
+}
+
+// parseColumns разбирает список колонок --columns: "1-10,11-25,26-".
+// Отдельное число N задает колонку из одного символа.
+//
+// Примеры:
+//
+//	"1-10,11-25,26-" → [{1 10} {11 25} {26 0}]
+//	"1-5,7" → [{1 5} {7 7}]
+func parseColumns(spec string) ([]columnRange, error) {
+	var columns []columnRange
+	for _, item := range strings.Split(spec, ",") {
+		invalid := fmt.Errorf("%w: %q", domain.ErrInvalidColumns, item)
+		from, to, isRange := strings.Cut(strings.TrimSpace(item), "-")
+
+		start, err := strconv.Atoi(from)
+		if err != nil || start < 1 {
+			return nil, invalid
+		}
+		column := columnRange{start: start, end: start}
+		if isRange {
+			column.end = 0
+			if to != "" {
+				if column.end, err = strconv.Atoi(to); err != nil || column.end < start {
+					return nil, invalid
+				}
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// formatColumns записывает колонки в формате --columns.
+func formatColumns(columns []columnRange) string {
+	items := make([]string, len(columns))
+	for i, column := range columns {
+		items[i] = strconv.Itoa(column.start) + "-"
+		if column.end > 0 {
+			items[i] += strconv.Itoa(column.end)
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// splitColumns вырезает из строки колонки фиксированной ширины. Позиции считаются
+// в символах, значения очищаются от пробелов по краям. Колонки, начинающиеся
+// за концом строки, не возвращаются.
+//
+// Пример:
+//
+//	"  PID TTY      CMD" с [{1 5} {6 11} {12 0}] → ["PID", "TTY", "CMD"]
+func splitColumns(line string, columns []columnRange) []string {
+	runes := []rune(line)
+	var fields []string
+	for _, column := range columns {
+		if column.start > len(runes) {
+			break
+		}
+		end := len(runes)
+		if column.end > 0 {
+			end = min(column.end, end)
+		}
+		fields = append(fields, strings.TrimSpace(string(runes[column.start-1:end])))
+	}
+	return fields
+}
+
+// inferColumns определяет колонки по строке заголовка header и строкам данных lines:
+// каждое слово заголовка начинает колонку, а граница между соседними словами ставится
+// в первой позиции промежутка, пустой во всех строках данных. Так значения, выровненные
+// по правому краю (PID в ps), и значения шире заголовка попадают в свою колонку.
+// Слово заголовка присоединяется к предыдущему, если в промежутке нет общей пустой
+// позиции ("Mounted on" в df с длинными путями) или под словом нет данных
+// ("CONTAINER ID" в docker ps). Последняя колонка продолжается до конца строки.
+//
+// Пример:
+//
+//	заголовок "  PID TTY      CMD" и строка "12345 pts/0    bash" → "1-5,6-11,12-"
+func inferColumns(header string, lines []string) []columnRange {
+	rows := make([][]rune, len(lines))
+	for i, line := range lines {
+		rows[i] = []rune(line)
+	}
+	blank := func(from, to int) bool {
+		for _, runes := range rows {
+			for pos := from; pos < min(to, len(runes)); pos++ {
+				if runes[pos] != ' ' && runes[pos] != '\t' {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	runes := []rune(header)
+	var columns []columnRange
+	for pos := 0; pos < len(runes); {
+		// Промежуток до следующего слова заголовка
+		gapStart := pos
+		for pos < len(runes) && (runes[pos] == ' ' || runes[pos] == '\t') {
+			pos++
+		}
+		if pos == len(runes) {
+			break
+		}
+		wordEnd := pos
+		for wordEnd < len(runes) && runes[wordEnd] != ' ' && runes[wordEnd] != '\t' {
+			wordEnd++
+		}
+		if len(columns) > 0 && !blank(pos, wordEnd) {
+			for gap := gapStart; gap < pos; gap++ {
+				if blank(gap, gap+1) {
+					columns[len(columns)-1].end = gap
+					columns = append(columns, columnRange{start: gap + 1})
+					break
+				}
+			}
+		} else if len(columns) == 0 {
+			columns = append(columns, columnRange{start: 1})
+		}
+		pos = wordEnd
+	}
+	if len(columns) > 0 {
+		columns[len(columns)-1].end = 0
+	}
+	return columns
+}
+
+// autoColumns определяет колонки --auto-columns по последней строке заголовка --header
+// и строкам после него и возвращает их в формате --columns.
+func autoColumns(input string, opts domain.SortOptions) string {
+	lines := strings.Split(input, "\n")
+	if opts.StripANSI {
+		for i, line := range lines {
+			lines[i] = stripANSI(line)
+		}
+	}
+	n := min(opts.Header, len(lines))
+	return formatColumns(inferColumns(lines[n-1], lines[n:]))
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected []columnRange
+	}{
+		{name: "ranges and open end", spec: "1-10,11-25,26-", expected: []columnRange{{1, 10}, {11, 25}, {26, 0}}},
+		{name: "single character", spec: "1-5, 7", expected: []columnRange{{1, 5}, {7, 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			columns, err := parseColumns(tt.spec)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, columns)
+			require.Equal(t, tt.expected, func() []columnRange {
+				again, _ := parseColumns(formatColumns(columns))
+				return again
+			}())
+		})
+	}
+
+	for _, spec := range []string{"0-5", "5-3", "a-b", "1-5,,6-", "-5"} {
+		t.Run("invalid "+spec, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseColumns(spec)
+			require.ErrorIs(t, err, domain.ErrInvalidColumns)
+		})
+	}
+}
+
+func TestSplitColumns(t *testing.T) {
+	columns := []columnRange{{1, 5}, {6, 11}, {12, 0}}
+
+	tests := []struct {
+		name     string
+		line     string
+		expected []string
+	}{
+		{name: "trimmed values", line: "12345 pts/0  vim a.txt", expected: []string{"12345", "pts/0", "vim a.txt"}},
+		{name: "short line", line: "    7 tty", expected: []string{"7", "tty"}},
+		{name: "runes, not bytes", line: "ёжик секрет итог", expected: []string{"ёжик", "секрет", "итог"}},
+		{name: "empty line", line: "", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, splitColumns(tt.line, columns))
+		})
+	}
+}
+
+func TestInferColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected string
+	}{
+		{
+			name:     "right-aligned numbers",
+			lines:    []string{"  PID TTY      CMD", "12345 pts/0    bash", "    7 ?        vim a.txt"},
+			expected: "1-5,6-11,12-",
+		},
+		{
+			name: "spaces inside header and values",
+			lines: []string{
+				"CONTAINER ID   IMAGE          STATUS",
+				"abc123         nginx:latest   Up 2 hours",
+				"def456         redis          Exited (0) 3 days ago",
+			},
+			expected: "1-12,13-27,28-",
+		},
+		{
+			name: "header words merged by long values",
+			lines: []string{
+				"Use% Mounted on",
+				" 26% /",
+				"  3% /boot/efi",
+			},
+			expected: "1-4,5-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, formatColumns(inferColumns(tt.lines[0], tt.lines[1:])))
+		})
+	}
+}
+
+func TestSortWithColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "field of fixed columns",
+			input:    "b   10 x y\na    2 z\nc  300 w",
+			opts:     domain.SortOptions{Columns: "1-4,5-6,7-", KeySpecs: []string{"3"}},
+			expected: "c  300 w\nb   10 x y\na    2 z",
+		},
+		{
+			name:     "auto columns keep header",
+			input:    "  PID TTY      CMD\n  300 pts/1    vim a.txt\n   42 pts/0    bash",
+			opts:     domain.SortOptions{AutoColumns: true, KeySpecs: []string{"1:n"}},
+			expected: "  PID TTY      CMD\n   42 pts/0    bash\n  300 pts/1    vim a.txt",
+		},
+		{
+			name:     "auto columns with order by name",
+			input:    "NAME      STATUS\nweb 1     Up 2 hours\napi       Exited (0)",
+			opts:     domain.SortOptions{AutoColumns: true, OrderBy: "status DESC"},
+			expected: "NAME      STATUS\nweb 1     Up 2 hours\napi       Exited (0)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Sort(tt.input, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := Sort("a b", domain.SortOptions{Columns: "1-2", Separator: ","})
+	require.ErrorIs(t, err, domain.ErrConflictOpts)
+}
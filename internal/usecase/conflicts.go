@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"unix_sort_lite/internal/domain"
+)
+
+// wholeLineSort сообщает, выбрана ли сортировка, которая сама определяет порядок строк
+// без ключей сравнения: --log-time, --stat-key или --near. Поле -k для --stat-key и --near
+// задает только источник значения.
+func wholeLineSort(opts domain.SortOptions) bool {
+	return opts.LogTime || opts.StatKey != "" || opts.Near != ""
+}
+
+// recordFormats возвращает число выбранных форматов записей: --csv, --jsonl, --logfmt,
+// --json, --table и многострочных записей --paragraph или --record-start.
+// Каждый формат сам разбирает ввод на записи, поэтому выбрать можно только один.
+func recordFormats(opts domain.SortOptions) int {
+	n := 0
+	for _, on := range []bool{opts.CSV, opts.JSONL, opts.Logfmt, opts.JSON, opts.Table, opts.Paragraph, opts.RecordStart != ""} {
+		if on {
+			n++
+		}
+	}
+	return n
+}
+
+// fieldFormat сообщает, разбирает ли формат записей поля по собственным правилам
+// (--csv, --jsonl, --logfmt, --json, --table): разделитель -t и колонки фиксированной
+// ширины к таким записям не применимы.
+func fieldFormat(opts domain.SortOptions) bool {
+	return opts.CSV || opts.JSONL || opts.Logfmt || opts.JSON || opts.Table
+}
+
+// checkConflicts проверяет сочетания флагов режимов сортировки и ключей.
+// Несовместимые сочетания дают domain.ErrConflictOpts.
+func checkConflicts(opts domain.SortOptions) error {
+	regex := len(opts.KeyRegex) > 0
+	keys := opts.OrderBy != "" || len(opts.Keys) > 0
+	columns := opts.Columns != "" || opts.AutoColumns
+
+	switch {
+	// Форматы записей не сочетаются друг с другом, с сортировками строк целиком
+	// и с ключами --key-regex
+	case recordFormats(opts) > 1,
+		recordFormats(opts) > 0 && (wholeLineSort(opts) || regex):
+		return domain.ErrConflictOpts
+	// Поля форматов разбираются по их правилам, а не по -t или колонкам
+	case fieldFormat(opts) && (opts.Separator != "" || columns):
+		return domain.ErrConflictOpts
+	// Колонки фиксированной ширины заменяют разделитель -t; --auto-columns сам
+	// закрепляет заголовок, поэтому не сочетается с записями и секциями
+	case opts.Columns != "" && opts.AutoColumns,
+		columns && (opts.Separator != "" || regex || opts.LogTime),
+		opts.AutoColumns && (opts.Paragraph || opts.RecordStart != "" || opts.SectionRegex != "" || opts.Sections):
+		return domain.ErrConflictOpts
+	// Секции сортируются построчно и не сочетаются с записями CSV и документом JSON
+	case (opts.SectionRegex != "" || opts.Sections) && (opts.CSV || opts.JSON):
+		return domain.ErrConflictOpts
+	// Таблицы и документ JSON находят заголовки сами, а ключи --jsonl и --logfmt
+	// задаются путями и именами, а не колонками заголовка
+	case opts.Header != 0 && (opts.Table || opts.JSON || opts.JSONL || opts.Logfmt),
+		opts.OrderBy != "" && (opts.JSONL || opts.Logfmt):
+		return domain.ErrConflictOpts
+	// Документ JSON сортирует массивы по --json-array-key, а не по ключам строк
+	case opts.JSON && (opts.Key || keys):
+		return domain.ErrConflictOpts
+	// Ключи --order-by или нескольких -k заменяют поле -k и --key-regex; --order-by
+	// и несколько -k задают ключи по-разному, поэтому вместе не используются
+	case keys && (opts.Key || regex || wholeLineSort(opts)),
+		opts.OrderBy != "" && len(opts.Keys) > 0:
+		return domain.ErrConflictOpts
+	// Ключ --key-regex заменяет поле -k; сортировка логов работает с записями целиком
+	case regex && (opts.Key || wholeLineSort(opts)),
+		opts.LogTime && opts.Key:
+		return domain.ErrConflictOpts
+	// Поля --geo-fields задают координаты только для сортировки по расстоянию
+	case len(opts.GeoFields) > 0 && opts.Near == "":
+		return domain.ErrConflictOpts
+	// Метрика --tiebreak-metric упорядочивает строки с равными ключами, поэтому требует
+	// -k, --order-by, --key-regex или записей с ключами и не применима к остальным сортировкам
+	case opts.Tiebreak != "" && (!(opts.Key || keys || regex || opts.CSV || opts.Table ||
+		opts.Paragraph || opts.RecordStart != "") || opts.JSONL || opts.Logfmt || wholeLineSort(opts)):
+		return domain.ErrConflictOpts
+	}
+	return nil
+}
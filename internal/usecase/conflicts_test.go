@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConflicts(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    domain.SortOptions
+		wantErr error
+	}{
+		{name: "csv with header and keys", opts: domain.SortOptions{CSV: true, Header: 1, OrderBy: "1"}},
+		{name: "paragraph with separator", opts: domain.SortOptions{Paragraph: true, Separator: ":", KeySpecs: []string{"2"}}},
+		{name: "stat key with field", opts: domain.SortOptions{StatKey: "size", KeySpecs: []string{"2"}}},
+		{name: "jsonl in sections", opts: domain.SortOptions{JSONL: true, Sections: true}},
+		{name: "two record formats", opts: domain.SortOptions{CSV: true, Logfmt: true}, wantErr: domain.ErrConflictOpts},
+		{name: "record format with whole line sort", opts: domain.SortOptions{Table: true, Near: "0,0"}, wantErr: domain.ErrConflictOpts},
+		{name: "field format with separator", opts: domain.SortOptions{JSONL: true, Separator: ","}, wantErr: domain.ErrConflictOpts},
+		{name: "columns with key regex", opts: domain.SortOptions{Columns: "1-3", KeyRegex: []string{`\d+`}}, wantErr: domain.ErrConflictOpts},
+		{name: "jsonl with header", opts: domain.SortOptions{JSONL: true, Header: 1}, wantErr: domain.ErrConflictOpts},
+		{name: "logfmt with header", opts: domain.SortOptions{Logfmt: true, Header: 1}, wantErr: domain.ErrConflictOpts},
+		{name: "order by with several -k", opts: domain.SortOptions{Header: 1, OrderBy: "b", KeySpecs: []string{"2", "1"}}, wantErr: domain.ErrConflictOpts},
+		{name: "order by with -k", opts: domain.SortOptions{Header: 1, OrderBy: "b", KeySpecs: []string{"2"}}, wantErr: domain.ErrConflictOpts},
+		{name: "key regex with -k", opts: domain.SortOptions{KeyRegex: []string{`\d+`}, KeySpecs: []string{"1"}}, wantErr: domain.ErrConflictOpts},
+		{name: "log time with -k", opts: domain.SortOptions{LogTime: true, KeySpecs: []string{"1"}}, wantErr: domain.ErrConflictOpts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Sort("a b\n2 1\n1 2", tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
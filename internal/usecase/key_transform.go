@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unix_sort_lite/internal/domain"
+)
+
+// Преобразования ключа для флагов --key-sub, --key-trim, --key-squeeze, --key-lower,
+// --key-prefix, --key-suffix и --key-reverse. В opts.KeyTransforms записываются
+// в виде "имя" или "имя=аргумент" в порядке флагов командной строки. Цепочка одна
+// на все ключи: она применяется к каждому ключу -k, --order-by и --key-regex,
+// ограничить преобразование одним из нескольких ключей нельзя.
+const (
+	transformSub     = "sub"     // замена по регулярному выражению s/RE/REPL/[gi]
+	transformTrim    = "trim"    // удаление пробельных символов по краям
+	transformSqueeze = "squeeze" // схлопывание пробельных символов в один пробел
+	transformLower   = "lower"   // нижний регистр
+	transformPrefix  = "prefix"  // первые N символов
+	transformSuffix  = "suffix"  // последние N символов
+	transformReverse = "reverse" // символы в обратном порядке
+)
+
+// keyTransform объединяет преобразования ключа в одно, применяя их в порядке specs.
+// Для пустого списка возвращается nil.
+//
+// Примеры:
+//
+//	["sub=s/^v//", "prefix=3"]: "v1.20.3" → "1.2"
+//	["trim", "squeeze", "lower"]: "  Hello   World " → "hello world"
+func keyTransform(specs []string) (func(string) string, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	chain := make([]func(string) string, 0, len(specs))
+	for _, spec := range specs {
+		fn, err := parseKeyTransform(spec)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, fn)
+	}
+	return chainModify(chain...), nil
+}
+
+// parseKeyTransform разбирает одно преобразование вида "имя" или "имя=аргумент".
+func parseKeyTransform(spec string) (func(string) string, error) {
+	name, arg, _ := strings.Cut(spec, "=")
+	switch name {
+	case transformSub:
+		return parseSubstitution(arg)
+	case transformTrim:
+		return strings.TrimSpace, nil
+	case transformSqueeze:
+		return squeezeSpaces, nil
+	case transformLower:
+		return strings.ToLower, nil
+	case transformPrefix, transformSuffix:
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidTransform, spec)
+		}
+		if name == transformPrefix {
+			return func(s string) string { return runePrefix(s, n) }, nil
+		}
+		return func(s string) string { return runeSuffix(s, n) }, nil
+	case transformReverse:
+		return reverseRunes, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidTransform, spec)
+	}
+}
+
+// parseSubstitution разбирает замену в синтаксисе sed: s/RE/REPL/FLAGS.
+// Разделителем служит символ после "s" ("s|/usr|~|"). В REPL "&" и "\1"–"\9" обозначают
+// найденный текст и группы, "\&" и "\\" — литеральные символы. Флаг g заменяет
+// все вхождения (по умолчанию только первое), флаг i отключает учет регистра.
+//
+// Примеры:
+//
+//	"s/^v//": "v1.2" → "1.2"
+//	"s/(\w+)@(\w+)/\2:\1/": "user@host" → "host:user"
+//	"s/-/./g": "2026-10-19" → "2026.10.19"
+func parseSubstitution(expr string) (func(string) string, error) {
+	invalid := fmt.Errorf("%w: %q", domain.ErrInvalidTransform, transformSub+"="+expr)
+	if len(expr) < 2 || expr[0] != 's' {
+		return nil, invalid
+	}
+
+	parts := splitSubstitution(expr[2:], expr[1])
+	if len(parts) != 3 {
+		return nil, invalid
+	}
+	pattern, repl, flags := parts[0], parts[1], parts[2]
+
+	global := false
+	for _, flag := range flags {
+		switch flag {
+		case 'g':
+			global = true
+		case 'i':
+			pattern = "(?i)" + pattern
+		default:
+			return nil, invalid
+		}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", invalid, err)
+	}
+	template := sedTemplate(repl)
+
+	return func(s string) string {
+		if global {
+			return re.ReplaceAllString(s, template)
+		}
+		match := re.FindStringSubmatchIndex(s)
+		if match == nil {
+			return s
+		}
+		dst := re.ExpandString([]byte(s[:match[0]]), template, s, match)
+		return string(dst) + s[match[1]:]
+	}, nil
+}
+
+// splitSubstitution делит "RE/REPL/FLAGS" по разделителю delim; экранированный
+// разделитель "\/" становится частью выражения.
+func splitSubstitution(s string, delim byte) []string {
+	var (
+		parts []string
+		cur   strings.Builder
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == delim:
+			cur.WriteByte(delim)
+			i++
+		case s[i] == '\\' && i+1 < len(s):
+			cur.WriteString(s[i : i+2])
+			i++
+		case s[i] == delim:
+			parts = append(parts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(s[i])
+		}
+	}
+	return append(parts, cur.String())
+}
+
+// sedTemplate переводит замену sed в шаблон regexp.Expand: "&" → "${0}", "\1" → "${1}", "$" → "$$".
+func sedTemplate(repl string) string {
+	var b strings.Builder
+	for i := 0; i < len(repl); i++ {
+		switch c := repl[i]; {
+		case c == '\\' && i+1 < len(repl):
+			i++
+			if next := repl[i]; next >= '0' && next <= '9' {
+				b.WriteString("${" + string(next) + "}")
+			} else if next == '$' {
+				b.WriteString("$$")
+			} else {
+				b.WriteByte(next)
+			}
+		case c == '&':
+			b.WriteString("${0}")
+		case c == '$':
+			b.WriteString("$$")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// squeezeSpaces заменяет каждую последовательность пробельных символов одним пробелом.
+// Пробелы по краям сохраняются (как один пробел), для их удаления служит --key-trim.
+func squeezeSpaces(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// runePrefix возвращает первые n символов строки.
+func runePrefix(s string, n int) string {
+	runes := []rune(s)
+	if n >= len(runes) {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// runeSuffix возвращает последние n символов строки.
+func runeSuffix(s string, n int) string {
+	runes := []rune(s)
+	if n >= len(runes) {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
+
+// reverseRunes переворачивает строку посимвольно: "example.com" → "moc.elpmaxe".
+func reverseRunes(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
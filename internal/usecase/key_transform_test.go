@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyTransform(t *testing.T) {
+	tests := []struct {
+		name     string
+		specs    []string
+		input    string
+		expected string
+	}{
+		{name: "strip prefix", specs: []string{"sub=s/^v//"}, input: "v1.2", expected: "1.2"},
+		{name: "first match only", specs: []string{"sub=s/-/./"}, input: "a-b-c", expected: "a.b-c"},
+		{name: "global replace", specs: []string{"sub=s/-/./g"}, input: "a-b-c", expected: "a.b.c"},
+		{name: "ignore case", specs: []string{"sub=s/x/y/gi"}, input: "XxX", expected: "yyy"},
+		{name: "groups and whole match", specs: []string{`sub=s/(\w+)@(\w+)/\2:\1 [&]/`}, input: "user@host", expected: "host:user [user@host]"},
+		{name: "custom delimiter", specs: []string{"sub=s|/usr|~|"}, input: "/usr/bin", expected: "~/bin"},
+		{name: "escaped delimiter", specs: []string{`sub=s/\//:/g`}, input: "a/b", expected: "a:b"},
+		{name: "literal dollar", specs: []string{"sub=s/^/$/"}, input: "5", expected: "$5"},
+		{name: "trim", specs: []string{"trim"}, input: "  a b  ", expected: "a b"},
+		{name: "squeeze keeps edges", specs: []string{"squeeze"}, input: "  a \t b  ", expected: " a b "},
+		{name: "lower", specs: []string{"lower"}, input: "ПрИвЕт", expected: "привет"},
+		{name: "prefix runes", specs: []string{"prefix=2"}, input: "ёжик", expected: "ёж"},
+		{name: "suffix runes", specs: []string{"suffix=3"}, input: "report.pdf", expected: "pdf"},
+		{name: "prefix longer than key", specs: []string{"prefix=10"}, input: "abc", expected: "abc"},
+		{name: "reverse", specs: []string{"reverse"}, input: "example.com", expected: "moc.elpmaxe"},
+		{name: "order matters", specs: []string{"sub=s/^v//", "prefix=3"}, input: "v1.20.3", expected: "1.2"},
+		{name: "chain", specs: []string{"trim", "squeeze", "lower"}, input: "  Hello   World ", expected: "hello world"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			transform, err := keyTransform(tt.specs)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, transform(tt.input))
+		})
+	}
+}
+
+func TestKeyTransformErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs []string
+	}{
+		{name: "unknown transform", specs: []string{"upper"}},
+		{name: "not a substitution", specs: []string{"sub=x/a/b/"}},
+		{name: "missing delimiter", specs: []string{"sub=s/a/b"}},
+		{name: "unknown flag", specs: []string{"sub=s/a/b/q"}},
+		{name: "bad regexp", specs: []string{"sub=s/(/b/"}},
+		{name: "negative prefix", specs: []string{"prefix=-1"}},
+		{name: "suffix without number", specs: []string{"suffix=x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := keyTransform(tt.specs)
+			require.ErrorIs(t, err, domain.ErrInvalidTransform)
+		})
+	}
+
+	t.Run("no transforms", func(t *testing.T) {
+		t.Parallel()
+		transform, err := keyTransform(nil)
+		require.NoError(t, err)
+		require.Nil(t, transform)
+	})
+}
+
+func TestSortByFieldKeyTransform(t *testing.T) {
+	t.Parallel()
+
+	transform, err := keyTransform([]string{"sub=s/^v//"})
+	require.NoError(t, err)
+
+	opts := domain.SortOptions{Key: true, Field: 2, Numeric: true}
+	result := SortByField("a v1.10\nb v1.9\nc v0.5", transform, opts)
+	require.Equal(t, "c v0.5\na v1.10\nb v1.9", result)
+}
@@ -1,7 +1,12 @@
 package usecase
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
+	"unix_sort_lite/internal/domain"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // trailingBlanks определяет символы, которые считаются trailing blanks
@@ -20,3 +25,118 @@ const trailingBlanks = " \t"
 func ignoreTrailingBlanks(s string) string {
 	return strings.TrimRight(s, trailingBlanks)
 }
+
+// stripANSI удаляет из строки escape-последовательности терминала (флаг --strip-ansi):
+// CSI ("\x1b[1;31m", "\x1b[K") и OSC ("\x1b]8;;url\x07", завершаемые BEL или "\x1b\\").
+// Незавершенная последовательность удаляется до конца строки.
+//
+// Примеры:
+//
+//	"\x1b[01;34mdir\x1b[0m" → "dir"
+//	"\x1b]8;;http://x\x07link\x1b]8;;\x07" → "link"
+func stripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\x1b' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '[':
+			// CSI: параметры 0x30–0x3F, промежуточные 0x20–0x2F, финальный байт 0x40–0x7E
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7E) {
+				j++
+			}
+			i = j
+		case ']':
+			// OSC: до BEL или ST ("\x1b\\")
+			j := i + 2
+			for j < len(s) && s[j] != '\a' && !(s[j] == '\x1b' && j+1 < len(s) && s[j+1] == '\\') {
+				j++
+			}
+			if j < len(s) && s[j] == '\x1b' {
+				j++
+			}
+			i = j
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// chainModify объединяет преобразования ключа в одно, применяя их слева направо.
+// Пустые (nil) преобразования пропускаются; без преобразований ключ не меняется.
+func chainModify(fns ...func(string) string) func(string) string {
+	var chain []func(string) string
+	for _, fn := range fns {
+		if fn != nil {
+			chain = append(chain, fn)
+		}
+	}
+
+	return func(s string) string {
+		for _, fn := range chain {
+			s = fn(s)
+		}
+		return s
+	}
+}
+
+// normalizeTransform возвращает приведение ключа к форме нормализации Unicode
+// (флаг --normalize): NFC, NFD, NFKC или NFKD, без учета регистра имени формы.
+// Для пустой формы возвращается nil.
+//
+// Примеры:
+//
+//	"e\u0301" с NFC → "\u00e9" (é из macOS совпадает с é из Linux)
+//	"ﬁ" с NFKC → "fi"
+func normalizeTransform(form string) (func(string) string, error) {
+	switch strings.ToUpper(form) {
+	case "":
+		return nil, nil
+	case "NFC":
+		return norm.NFC.String, nil
+	case "NFD":
+		return norm.NFD.String, nil
+	case "NFKC":
+		return norm.NFKC.String, nil
+	case "NFKD":
+		return norm.NFKD.String, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrInvalidNormalization, form)
+	}
+}
+
+// accentFolds заменяет буквы, диакритика которых не отделяется при разложении NFD.
+var accentFolds = map[rune]rune{
+	'ø': 'o', 'Ø': 'O', 'ł': 'l', 'Ł': 'L', 'đ': 'd', 'Đ': 'D', 'ħ': 'h', 'Ħ': 'H', 'ı': 'i',
+}
+
+// foldAccents удаляет диакритические знаки (флаг --ignore-accents): раскладывает
+// строку по NFD, отбрасывает комбинируемые знаки и собирает результат обратно в NFC.
+// Русские "ё" и "й" также сводятся к "е" и "и".
+//
+// Примеры:
+//
+//	"Crème brûlée" → "Creme brulee"
+//	"Łódź" → "Lodz"
+//	"ёжик" → "ежик"
+func foldAccents(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if folded, ok := accentFolds[r]; ok {
+			r = folded
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC.String(b.String())
+}
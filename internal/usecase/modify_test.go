@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"testing"
+	"unix_sort_lite/internal/domain"
 
 	"github.com/stretchr/testify/require"
 )
@@ -67,3 +68,94 @@ func TestIgnoreTrailingBlanks(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeTransform(t *testing.T) {
+	tests := []struct {
+		name     string
+		form     string
+		input    string
+		expected string
+	}{
+		{name: "NFC composes", form: "NFC", input: "e\u0301", expected: "\u00e9"},
+		{name: "NFD decomposes", form: "nfd", input: "\u00e9", expected: "e\u0301"},
+		{name: "NFKC folds compatibility", form: "NFKC", input: "ﬁle", expected: "file"},
+		{name: "NFKD folds and decomposes", form: "NFKD", input: "²é", expected: "2e\u0301"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			normalize, err := normalizeTransform(tt.form)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, normalize(tt.input))
+		})
+	}
+
+	t.Run("empty form", func(t *testing.T) {
+		t.Parallel()
+		normalize, err := normalizeTransform("")
+		require.NoError(t, err)
+		require.Nil(t, normalize)
+	})
+
+	t.Run("unknown form", func(t *testing.T) {
+		t.Parallel()
+		_, err := normalizeTransform("NFX")
+		require.ErrorIs(t, err, domain.ErrInvalidNormalization)
+	})
+}
+
+func TestFoldAccents(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "composed letters", input: "Crème brûlée", expected: "Creme brulee"},
+		{name: "decomposed letters", input: "Cre\u0300me", expected: "Creme"},
+		{name: "letters without decomposition", input: "Łódź Øresund", expected: "Lodz Oresund"},
+		{name: "russian yo", input: "ёжик", expected: "ежик"},
+		{name: "plain text", input: "hello", expected: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, foldAccents(tt.input))
+		})
+	}
+}
+
+func TestChainModify(t *testing.T) {
+	t.Parallel()
+
+	normalize, err := normalizeTransform("NFC")
+	require.NoError(t, err)
+
+	modify := chainModify(ignoreTrailingBlanks, nil, normalize)
+	require.Equal(t, "\u00e9", modify("e\u0301  "))
+	require.Equal(t, "abc ", chainModify()("abc "))
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "sgr colors", input: "\x1b[01;34mdir\x1b[0m", expected: "dir"},
+		{name: "erase line", input: "text\x1b[K", expected: "text"},
+		{name: "osc hyperlink with bel", input: "\x1b]8;;http://x\x07link\x1b]8;;\x07", expected: "link"},
+		{name: "osc with string terminator", input: "\x1b]0;title\x1b\\body", expected: "body"},
+		{name: "unterminated sequence", input: "abc\x1b[31", expected: "abc"},
+		{name: "lone escape kept", input: "a\x1b", expected: "a\x1b"},
+		{name: "plain text", input: "plain", expected: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, stripANSI(tt.input))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneticCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		encode   func(string) string
+		input    string
+		expected string
+	}{
+		{name: "soundex basic", encode: soundex, input: "Robert", expected: "R163"},
+		{name: "soundex same code across h", encode: soundex, input: "Ashcraft", expected: "A261"},
+		{name: "soundex vowel separates codes", encode: soundex, input: "Tymczak", expected: "T522"},
+		{name: "soundex padding", encode: soundex, input: "Lee", expected: "L000"},
+		{name: "soundex non-latin word", encode: soundex, input: "Иван", expected: "иван"},
+		{name: "metaphone th", encode: doubleMetaphone, input: "Smith", expected: "SM0"},
+		{name: "metaphone sch", encode: doubleMetaphone, input: "Schmidt", expected: "XMT"},
+		{name: "metaphone silent start", encode: doubleMetaphone, input: "Knight", expected: "NT"},
+		{name: "metaphone ph", encode: doubleMetaphone, input: "Philip", expected: "FLP"},
+		{name: "metaphone spanish j", encode: doubleMetaphone, input: "Jose", expected: "HS"},
+		{name: "russian vowels", encode: russianMetaphone, input: "Алексей", expected: "АЛИКСИ"},
+		{name: "russian final devoicing", encode: russianMetaphone, input: "Дуб", expected: "ДУП"},
+		{name: "russian double letters", encode: russianMetaphone, input: "Петрофф", expected: "ПИТРАФ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, tt.encode(tt.input))
+		})
+	}
+}
+
+func TestPhoneticTransform(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme string
+		a, b   string
+	}{
+		{name: "soundex spelling variants", scheme: "soundex", a: "Smith", b: "Smyth"},
+		{name: "metaphone spelling variants", scheme: "metaphone", a: "Catherine", b: "Kathryn"},
+		{name: "metaphone every word", scheme: "metaphone", a: "John Smith", b: "Jon Smyth"},
+		{name: "cyrillic russian variants", scheme: "cyrillic", a: "Алексей", b: "Алексеи"},
+		{name: "cyrillic russian devoicing", scheme: "cyrillic", a: "Петров", b: "Петрофф"},
+		{name: "cyrillic latin words", scheme: "cyrillic", a: "Filip", b: "Philip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			encode, err := phoneticTransform(tt.scheme)
+			require.NoError(t, err)
+			require.Equal(t, encode(tt.a), encode(tt.b))
+		})
+	}
+
+	t.Run("empty scheme", func(t *testing.T) {
+		t.Parallel()
+		encode, err := phoneticTransform("")
+		require.NoError(t, err)
+		require.Nil(t, encode)
+	})
+
+	t.Run("unknown scheme", func(t *testing.T) {
+		t.Parallel()
+		_, err := phoneticTransform("nysiis")
+		require.ErrorIs(t, err, domain.ErrInvalidPhonetic)
+	})
+}
+
+func TestUniquePhonetic(t *testing.T) {
+	encode, err := phoneticTransform("metaphone")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		field    int
+		expected string
+	}{
+		{
+			name:     "whole line by sound",
+			input:    "Smith\nSmyth\nJones",
+			expected: "Smith\nJones",
+		},
+		{
+			name:     "field by sound",
+			input:    "1 Smith\n2 Smyth\n3 Schmidt",
+			field:    2,
+			expected: "1 Smith\n3 Schmidt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, Unique(tt.input, encode, domain.SortOptions{Field: tt.field}))
+		})
+	}
+}
@@ -13,10 +13,22 @@ func Sort(input string, opts domain.SortOptions) (string, error) {
 		modify    func(string) string
 	)
 
+	// Разбор ключей -k: номер поля, ключи с типами или пути JSON
+	if err := parseKeySpecs(&opts); err != nil {
+		return "", err
+	}
 	// Валидация: флаг -k требует корректный номер поля
 	if opts.Key && opts.Field < 1 {
 		return "", domain.ErrInvalideField
 	}
+	// Валидация: количество строк заголовка; --order-by разрешает колонки по заголовку,
+	// --auto-columns и --table находят заголовок сами
+	if opts.Header < 0 {
+		return "", domain.ErrInvalidHeader
+	}
+	if opts.OrderBy != "" && opts.Header == 0 && !opts.AutoColumns && !opts.Table {
+		return "", domain.ErrOrderByHeader
+	}
 
 	// Подсчет взаимоисключающих типов сортировки
 	if opts.Numeric {
@@ -25,21 +37,227 @@ func Sort(input string, opts domain.SortOptions) (string, error) {
 	if opts.Month {
 		sortTypes++
 	}
+	if opts.Weekday {
+		sortTypes++
+	}
 	if opts.HumanNumeric {
 		sortTypes++
 	}
+	if opts.Date {
+		sortTypes++
+	}
+	if opts.Duration {
+		sortTypes++
+	}
+	if opts.LogTime {
+		sortTypes++
+	}
+	if len(opts.Order) > 0 {
+		sortTypes++
+	}
+	if opts.IP {
+		sortTypes++
+	}
+	if opts.Domain {
+		sortTypes++
+	}
+	if opts.Email {
+		sortTypes++
+	}
+	if opts.URL {
+		sortTypes++
+	}
+	if opts.Path {
+		sortTypes++
+	}
+	if opts.StatKey != "" {
+		sortTypes++
+	}
+	if opts.Metric != "" {
+		sortTypes++
+	}
+	if opts.Near != "" {
+		sortTypes++
+	}
 	// Проверка конфликтующих флагов, например, -nM
 	if sortTypes > 1 {
 		return "", domain.ErrConflictOpts
 	}
+	// Режим документа JSON сортирует массивы внутри одного документа, а не строки ввода
+	if !opts.JSON && (len(opts.JSONArrays) > 0 || opts.SortObjectKeys) {
+		return "", domain.ErrJSONDocument
+	}
+	// Проверка сочетаний режимов, форматов записей и ключей
+	if err := checkConflicts(opts); err != nil {
+		return "", err
+	}
 
+	// Валидация: зона для дат без смещения должна существовать
+	if _, err := newDateParser(opts); err != nil {
+		return "", err
+	}
+	// Валидация: размещение значений вне пользовательского списка
+	if _, err := newCustomOrder(opts); err != nil {
+		return "", err
+	}
+	// Валидация: атрибут файла и политика для отсутствующих файлов
+	if err := validateStatOptions(opts); err != nil {
+		return "", err
+	}
+	// Валидация: разделитель и кавычка CSV
+	if _, err := newCSVDialect(opts); err != nil {
+		return "", err
+	}
+	// Валидация: диапазоны колонок фиксированной ширины
+	if opts.Columns != "" {
+		if _, err := parseColumns(opts.Columns); err != nil {
+			return "", err
+		}
+	}
+	// Валидация: выражение заголовков секций
+	if _, err := newSectionRegex(opts); err != nil {
+		return "", err
+	}
+	// Валидация: начало многострочной записи и строка ключа в ней
+	if _, err := newRecordStart(opts); err != nil {
+		return "", err
+	}
+	if opts.KeyLine < 0 {
+		return "", domain.ErrInvalidKeyLine
+	}
+	// Валидация: размещение null и отсутствующих значений JSON
+	switch opts.JSONNull {
+	case "", domain.PlaceFirst, domain.PlaceLast:
+	default:
+		return "", domain.ErrInvalidPlace
+	}
+	// Валидация: размещение строк без ключа logfmt
+	switch opts.LogfmtMissing {
+	case "", domain.PlaceFirst, domain.PlaceLast:
+	default:
+		return "", domain.ErrInvalidPlace
+	}
+	// Валидация: пути к массивам и ключ элементов документа JSON
+	if err := validateJSONDocument(opts); err != nil {
+		return "", err
+	}
+	// Валидация: выражения --key-regex и политика для несовпавших строк
+	if _, err := newRegexKeys(opts); err != nil {
+		return "", err
+	}
+	// Валидация: метрики строки для сортировки и для разрешения равенства полей
+	if err := validateMetric(opts.Metric); err != nil {
+		return "", err
+	}
+	if err := validateMetric(opts.Tiebreak); err != nil {
+		return "", err
+	}
+	// Валидация: опорная точка для сортировки по расстоянию
+	if opts.Near != "" {
+		if _, err := parseNear(opts.Near); err != nil {
+			return "", err
+		}
+	}
+	if err := validateGeoFields(opts.GeoFields); err != nil {
+		return "", err
+	}
+
+	// Валидация: локаль и порядок регистра для сравнения текста
+	if _, err := newCollation(opts); err != nil {
+		return "", err
+	}
+	// Валидация: форма нормализации Unicode и фонетическая схема ключа
+	normalize, err := normalizeTransform(opts.Normalize)
+	if err != nil {
+		return "", err
+	}
+	phonetic, err := phoneticTransform(opts.Phonetic)
+	if err != nil {
+		return "", err
+	}
+	// Валидация: пользовательские преобразования ключа
+	transform, err := keyTransform(opts.KeyTransforms)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.AutoColumns {
+		// Колонки определяются по заголовку --auto-columns флаг, заголовок закрепляется
+		opts.Header = max(opts.Header, 1)
+		opts.Columns, opts.AutoColumns = autoColumns(input, opts), false
+	}
+	if opts.Header > 0 {
+		// Заголовок закрепляется, сортируется только тело --header флаг
+		return sortWithHeader(input, opts)
+	}
+	if opts.SectionRegex != "" || opts.Sections {
+		// Заголовки секций остаются на месте, тела сортируются независимо --section-regex флаг
+		return sortSections(input, opts)
+	}
+
+	// Преобразования ключа применяются по порядку: --strip-ansi, -b, пользовательские --key-*,
+	// --normalize, --ignore-accents, --phonetic
+	var ansi, blanks, accents func(string) string
+	if opts.StripANSI {
+		ansi = stripANSI
+	}
 	if opts.IgnoreBlanks {
-		modify = ignoreTrailingBlanks
-	} else {
-		modify = func(s string) string { return s } // Identity функция
+		blanks = ignoreTrailingBlanks
+	}
+	if opts.IgnoreAccents {
+		accents = foldAccents
 	}
+	modify = chainModify(ansi, blanks, transform, normalize, accents, phonetic)
 
 	switch {
+	case opts.LogTime:
+		// Хронологическая сортировка логов --log-time флаг, -r и -u применяются к записям
+		result = SortByLogTime(input, modify, opts)
+	case opts.StatKey != "":
+		// Сортировка путей по метаданным файлов --stat-key флаг, путь берется из поля -k,
+		// -r меняет порядок атрибутов, отсутствующие файлы размещаются по --stat-missing
+		if result, err = SortByStat(input, modify, opts); err != nil {
+			return "", err
+		}
+	case opts.Near != "":
+		// Сортировка по расстоянию до точки --near флаг, координаты из --geo-fields или -k,
+		// -r меняет порядок расстояний, строки без координат остаются последними
+		result = SortByGeo(input, modify, opts)
+	case opts.JSON:
+		// Сортировка массивов документа JSON --json флаг, -r и -u применяются к элементам
+		if result, err = SortJSONDocument(input, modify, opts); err != nil {
+			return "", err
+		}
+	case opts.Paragraph || opts.RecordStart != "":
+		// Сортировка многострочных записей --paragraph и --record-start флаги, ключ из --key-line
+		result = SortMultiline(input, modify, opts)
+	case opts.Table:
+		// Сортировка строк тела таблиц Markdown и ASCII --table флаг, ключи -k или --order-by
+		if result, err = SortTables(input, modify, opts); err != nil {
+			return "", err
+		}
+	case opts.Logfmt:
+		// Сортировка строк logfmt по именам ключей -k --logfmt флаг, строки выводятся без изменений
+		result = SortByLogfmt(input, modify, opts)
+	case opts.JSONL:
+		// Сортировка JSON Lines по путям -k --jsonl флаг, строки выводятся без изменений
+		result = SortByJSONL(input, modify, opts)
+	case len(opts.Keys) > 0:
+		// Многоключевая сортировка по колонкам заголовка --order-by или ключам -k N:type
+		if result, err = SortByKeys(input, modify, opts); err != nil {
+			return "", err
+		}
+	case opts.CSV:
+		// Сортировка записей CSV --csv флаг, ключ из ячейки -k, -r и -u применяются к записям
+		if result, err = SortByCSV(input, modify, opts); err != nil {
+			return "", err
+		}
+	case len(opts.KeyRegex) > 0:
+		// Сортировка по ключам из регулярных выражений --key-regex флаг, тип ключа из -n, -M, ...,
+		// -r меняет порядок ключей, несовпавшие строки размещаются по --regex-missing
+		if result, err = SortByRegex(input, modify, opts); err != nil {
+			return "", err
+		}
 	case opts.Key:
 		// Сортировка по N-му полю -k флаг
 		result = SortByField(input, modify, opts)
@@ -49,19 +267,54 @@ func Sort(input string, opts domain.SortOptions) (string, error) {
 	case opts.Month:
 		// Сортировка по месяцам -M флаг
 		result = SortByMonth(input, modify, opts)
+	case opts.Weekday:
+		// Сортировка по дням недели --weekday-sort флаг
+		result = SortByWeekday(input, modify, opts)
 	case opts.HumanNumeric:
 		// Human-readable сортировка -h флаг
 		result = SortByHumanNumeric(input, modify, opts)
+	case opts.Date:
+		// Хронологическая сортировка --date-sort флаг
+		result = SortByDate(input, modify, opts)
+	case opts.Duration:
+		// Сортировка по длительности --duration-sort флаг
+		result = SortByDuration(input, modify, opts)
+	case opts.IP:
+		// Сортировка по IP-адресам --ip-sort флаг
+		result = SortByIP(input, modify, opts)
+	case opts.Domain:
+		// Сортировка доменных имен --domain-sort флаг
+		result = SortByDomain(input, modify, opts)
+	case opts.Email:
+		// Сортировка адресов электронной почты --email-sort флаг
+		result = SortByEmail(input, modify, opts)
+	case opts.URL:
+		// Сортировка URL --url-sort флаг
+		result = SortByURL(input, modify, opts)
+	case opts.Path:
+		// Сортировка путей по компонентам --path-sort флаг
+		result = SortByPath(input, modify, opts)
+	case opts.Metric != "":
+		// Сортировка по метрике строки --metric флаг
+		result = SortByMetric(input, modify, opts)
+	case len(opts.Order) > 0:
+		// Сортировка по пользовательскому списку --order флаг
+		result = SortByOrder(input, modify, opts)
 	default:
 		// Лексикографическая сортировка по умолчанию
-		result = SortDefault(input, modify)
+		result = SortDefault(input, modify, opts)
 	}
 
-	if opts.Reverse {
+	// Сортировки записей (--csv, --order-by, -k с типами, --jsonl, --logfmt, --paragraph,
+	// --record-start), таблиц (--table) и документа JSON (--json) применяют -r и -u сами;
+	// --log-time, --stat-key, --near и --key-regex применяют -r сами, не меняя размещения
+	// строк без метки, файла, координат или совпадения
+	records := recordFormats(opts) > 0 || len(opts.Keys) > 0
+	if opts.Reverse && !wholeLineSort(opts) && len(opts.KeyRegex) == 0 && !records {
 		result = Reverse(result)
 	}
-	if opts.Unique {
-		result = Unique(result, opts.Field)
+	if opts.Unique && !opts.LogTime && !records {
+		result = Unique(result, modify, opts)
 	}
 
 	return result, nil
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+	"unix_sort_lite/internal/domain"
+)
+
+// csvDialect описывает формат CSV: разделитель, символ кавычки и нестрогий разбор кавычек.
+type csvDialect struct {
+	delim rune
+	quote rune
+	lazy  bool
+}
+
+// newCSVDialect строит формат CSV из флагов --csv-delimiter, --csv-quote и --lazy-quotes.
+// По умолчанию используются запятая и двойная кавычка (RFC 4180); "\t" обозначает табуляцию.
+func newCSVDialect(opts domain.SortOptions) (csvDialect, error) {
+	dialect := csvDialect{delim: ',', quote: '"', lazy: opts.LazyQuotes}
+
+	for _, opt := range []struct {
+		value string
+		dst   *rune
+	}{
+		{opts.CSVDelimiter, &dialect.delim},
+		{opts.CSVQuote, &dialect.quote},
+	} {
+		value := opt.value
+		if value == `\t` {
+			value = "\t"
+		}
+		if value == "" {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(value)
+		if size != len(value) || r == utf8.RuneError || r == '\n' || r == '\r' {
+			return dialect, fmt.Errorf("%w: %q", domain.ErrInvalidCSVDialect, opt.value)
+		}
+		*opt.dst = r
+	}
+
+	if dialect.delim == dialect.quote {
+		return dialect, fmt.Errorf("%w: %q", domain.ErrInvalidCSVDialect, string(dialect.quote))
+	}
+	return dialect, nil
+}
+
+// record хранит запись: исходный текст (возможно, из нескольких строк) и значения полей.
+type record struct {
+	raw    string
+	fields []string
+}
+
+// parseCSV разбирает записи CSV по RFC 4180: ячейки в кавычках могут содержать разделитель,
+// переводы строк и удвоенные кавычки (""). Исходный текст записи сохраняется без изменений,
+// "\r" в конце записи не входит в значение последней ячейки. Пустая строка дает запись без ячеек.
+// С lazy кавычка внутри ячейки без кавычек и одиночная кавычка внутри ячейки в кавычках
+// считаются обычными символами, а незакрытая кавычка продолжается до конца ввода.
+//
+// Примеры:
+//
+//	`1,"Smith, John"` → ["1", "Smith, John"]
+//	"2,\"line1\nline2\"" → ["2", "line1\nline2"] (одна запись из двух строк)
+//	`3,"say ""hi"""` → ["3", `say "hi"`]
+func parseCSV(s string, dialect csvDialect) ([]record, error) {
+	var (
+		records []record
+		fields  []string
+		field   strings.Builder
+		start   int  // начало текущей записи
+		line    = 1  // номер строки для сообщений об ошибках
+		quoted  bool // внутри ячейки в кавычках
+		closed  bool // кавычка ячейки закрыта, ожидается разделитель или конец записи
+	)
+
+	invalid := func(msg string) error {
+		return fmt.Errorf("%w: line %d: %s", domain.ErrInvalidCSV, line, msg)
+	}
+	endField := func() {
+		fields = append(fields, field.String())
+		field.Reset()
+		quoted, closed = false, false
+	}
+	endRecord := func(end int) {
+		raw := s[start:end]
+		if raw != "" || len(fields) > 0 {
+			endField()
+			last := len(fields) - 1
+			fields[last] = strings.TrimSuffix(fields[last], "\r")
+		}
+		records = append(records, record{raw: raw, fields: fields})
+		fields = nil
+	}
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		next, _ := utf8.DecodeRuneInString(s[i+size:])
+
+		switch {
+		case quoted && r == dialect.quote && next == dialect.quote:
+			// Удвоенная кавычка внутри ячейки в кавычках
+			field.WriteRune(r)
+			size += utf8.RuneLen(next)
+		case quoted && r == dialect.quote:
+			if i+size < len(s) && next != dialect.delim && next != '\n' && next != '\r' {
+				if !dialect.lazy {
+					return nil, invalid(`extraneous or missing " in quoted field`)
+				}
+				field.WriteRune(r)
+				break
+			}
+			quoted, closed = false, true
+		case quoted:
+			if r == '\n' {
+				line++
+			}
+			field.WriteRune(r)
+		case r == dialect.delim:
+			endField()
+		case r == '\n':
+			endRecord(i)
+			start = i + size
+			line++
+		case closed:
+			if r != '\r' {
+				return nil, invalid(`extraneous or missing " in quoted field`)
+			}
+			field.WriteRune(r)
+		case r == dialect.quote && field.Len() == 0:
+			quoted = true
+		case r == dialect.quote && !dialect.lazy:
+			return nil, invalid(`bare " in non-quoted field`)
+		default:
+			field.WriteRune(r)
+		}
+		i += size
+	}
+
+	if quoted && !dialect.lazy {
+		return nil, invalid(`extraneous or missing " in quoted field`)
+	}
+	endRecord(len(s))
+	return records, nil
+}
+
+// SortByCSV выполняет сортировку записей CSV (флаг --csv).
+// Записи разбираются по RFC 4180 (формат задают --csv-delimiter, --csv-quote и --lazy-quotes),
+// сравниваются по значению ячейки -k N по типу сортировки (-n, -M, --date-sort, ...),
+// а без -k — по всем ячейкам слева направо. Записи без ячейки N идут первыми, как в SortByField.
+// Многострочные записи не разрываются, каждая запись выводится в исходном виде байт в байт.
+// Флаги -r и -u применяются к записям, а не к строкам.
+//
+// Примеры:
+//
+//	"2,\"b, x\"\n1,\"a\nb\"" с -k 1 -n → "1,\"a\nb\"\n2,\"b, x\""
+//	`b,"Smith, John"` и `a,"Doe, Jane"` с -k 2 → сначала "Doe, Jane"
+func SortByCSV(s string, modify func(string) string, opts domain.SortOptions) (string, error) {
+	dialect, _ := newCSVDialect(opts) // формат проверяется в Sort
+	records, err := parseCSV(s, dialect)
+	if err != nil {
+		return "", err
+	}
+
+	less := keyComparator(opts)
+	compare := func(i, j record) int {
+		iFields, jFields := i.fields, j.fields
+		if opts.Key {
+			if len(iFields) < opts.Field || len(jFields) < opts.Field {
+				return len(iFields) - len(jFields)
+			}
+			iFields, jFields = iFields[opts.Field-1:opts.Field], jFields[opts.Field-1:opts.Field]
+		}
+		for k := 0; k < len(iFields) && k < len(jFields); k++ {
+			iKey, jKey := modify(iFields[k]), modify(jFields[k])
+			if less(iKey, jKey) {
+				return -1
+			}
+			if less(jKey, iKey) {
+				return 1
+			}
+		}
+		return len(iFields) - len(jFields)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if cmp := compare(records[i], records[j]); cmp != 0 {
+			return cmp < 0
+		}
+		if opts.Tiebreak == "" {
+			return false
+		}
+		// Ключи равны: сравнение записей целиком по метрике (флаг --tiebreak-metric)
+		return lineMetric(modify(records[i].raw), opts.Tiebreak) < lineMetric(modify(records[j].raw), opts.Tiebreak)
+	})
+
+	return joinRecords(records, modify, opts), nil
+}
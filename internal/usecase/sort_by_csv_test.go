@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	comma := csvDialect{delim: ',', quote: '"'}
+
+	tests := []struct {
+		name     string
+		input    string
+		dialect  csvDialect
+		expected []record
+	}{
+		{
+			name:    "quoted delimiter",
+			input:   `1,"Smith, John"`,
+			dialect: comma,
+			expected: []record{
+				{raw: `1,"Smith, John"`, fields: []string{"1", "Smith, John"}},
+			},
+		},
+		{
+			name:    "escaped quotes",
+			input:   `3,"say ""hi"""`,
+			dialect: comma,
+			expected: []record{
+				{raw: `3,"say ""hi"""`, fields: []string{"3", `say "hi"`}},
+			},
+		},
+		{
+			name:    "embedded newline keeps one record",
+			input:   "2,\"line1\nline2\"\n3,x",
+			dialect: comma,
+			expected: []record{
+				{raw: "2,\"line1\nline2\"", fields: []string{"2", "line1\nline2"}},
+				{raw: "3,x", fields: []string{"3", "x"}},
+			},
+		},
+		{
+			name:    "empty cells and empty line",
+			input:   ",\"\",\n",
+			dialect: comma,
+			expected: []record{
+				{raw: `,"",`, fields: []string{"", "", ""}},
+				{raw: ""},
+			},
+		},
+		{
+			name:    "crlf line endings",
+			input:   "a,\"b\"\r\nc,d\r\n",
+			dialect: comma,
+			expected: []record{
+				{raw: "a,\"b\"\r", fields: []string{"a", "b"}},
+				{raw: "c,d\r", fields: []string{"c", "d"}},
+				{raw: ""},
+			},
+		},
+		{
+			name:    "custom dialect",
+			input:   "a;'x;y'",
+			dialect: csvDialect{delim: ';', quote: '\''},
+			expected: []record{
+				{raw: "a;'x;y'", fields: []string{"a", "x;y"}},
+			},
+		},
+		{
+			name:    "lazy quotes",
+			input:   `a"b,"c"d"`,
+			dialect: csvDialect{delim: ',', quote: '"', lazy: true},
+			expected: []record{
+				{raw: `a"b,"c"d"`, fields: []string{`a"b`, `c"d`}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			records, err := parseCSV(tt.input, tt.dialect)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, records)
+		})
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	comma := csvDialect{delim: ',', quote: '"'}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "bare quote", input: `a"b,c`},
+		{name: "text after closing quote", input: `"a"b,c`},
+		{name: "unterminated quote", input: "1,ok\n2,\"open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseCSV(tt.input, comma)
+			require.ErrorIs(t, err, domain.ErrInvalidCSV)
+		})
+	}
+}
+
+func TestNewCSVDialect(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     domain.SortOptions
+		expected csvDialect
+		wantErr  bool
+	}{
+		{name: "defaults", opts: domain.SortOptions{}, expected: csvDialect{delim: ',', quote: '"'}},
+		{name: "tab escape", opts: domain.SortOptions{CSVDelimiter: `\t`}, expected: csvDialect{delim: '\t', quote: '"'}},
+		{name: "unicode delimiter", opts: domain.SortOptions{CSVDelimiter: "§", LazyQuotes: true}, expected: csvDialect{delim: '§', quote: '"', lazy: true}},
+		{name: "multi-character delimiter", opts: domain.SortOptions{CSVDelimiter: "::"}, wantErr: true},
+		{name: "same delimiter and quote", opts: domain.SortOptions{CSVQuote: ","}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dialect, err := newCSVDialect(tt.opts)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidCSVDialect)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, dialect)
+		})
+	}
+}
+
+func TestSortByCSV(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "numeric cell keeps multi-line records",
+			input:    "3,\"Smith, John\"\n1,\"multi\nline\"\n2,\"say \"\"hi\"\"\"",
+			opts:     domain.SortOptions{CSV: true, Key: true, Field: 1, Numeric: true},
+			expected: "1,\"multi\nline\"\n2,\"say \"\"hi\"\"\"\n3,\"Smith, John\"",
+		},
+		{
+			name:     "key on parsed value, not quotes",
+			input:    "b,\"Smith, John\"\na,Doe",
+			opts:     domain.SortOptions{CSV: true, Key: true, Field: 2},
+			expected: "a,Doe\nb,\"Smith, John\"",
+		},
+		{
+			name:     "all cells without key",
+			input:    "b,1\na,2\na,1",
+			opts:     domain.SortOptions{CSV: true},
+			expected: "a,1\na,2\nb,1",
+		},
+		{
+			name:     "reverse records",
+			input:    "1,\"a\nb\"\n2,c",
+			opts:     domain.SortOptions{CSV: true, Key: true, Field: 1, Numeric: true, Reverse: true},
+			expected: "2,c\n1,\"a\nb\"",
+		},
+		{
+			name:     "unique by cell",
+			input:    "1,\"x\ny\"\n2,\"x\ny\"\n3,z",
+			opts:     domain.SortOptions{CSV: true, Key: true, Field: 2, Unique: true},
+			expected: "1,\"x\ny\"\n3,z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := SortByCSV(tt.input, identity, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
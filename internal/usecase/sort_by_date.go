@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unix_sort_lite/internal/domain"
+)
+
+// defaultDateLayouts определяет форматы дат, которые распознаются без --date-format.
+// Покрывает RFC 3339 и распространенные варианты ISO 8601 (с пробелом вместо T,
+// без секунд, без зоны, только дата).
+var defaultDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// dateParser разбирает даты по списку форматов и приводит их к UTC.
+type dateParser struct {
+	layouts []string
+	loc     *time.Location
+}
+
+// newDateParser создает парсер дат из опций --date-format и --timezone.
+// Значения без зоны интерпретируются в зоне --timezone (по умолчанию UTC).
+// При некорректной зоне возвращается ошибка и парсер с зоной UTC.
+func newDateParser(opts domain.SortOptions) (dateParser, error) {
+	parser := dateParser{layouts: defaultDateLayouts, loc: time.UTC}
+	if len(opts.DateFormats) > 0 {
+		parser.layouts = opts.DateFormats
+	}
+
+	if opts.Timezone == "" {
+		return parser, nil
+	}
+	loc, err := loadTimezone(opts.Timezone)
+	if err != nil {
+		return parser, err
+	}
+	parser.loc = loc
+	return parser, nil
+}
+
+// loadTimezone загружает зону по имени из базы IANA (Europe/Moscow, UTC, Local)
+// или по фиксированному смещению (+03:00, -0700).
+func loadTimezone(name string) (*time.Location, error) {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc, nil
+	}
+	for _, layout := range []string{"-07:00", "-0700", "-07"} {
+		if t, err := time.Parse(layout, name); err == nil {
+			_, offset := t.Zone()
+			return time.FixedZone(name, offset), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", domain.ErrInvalidTimezone, name)
+}
+
+// parse пробует разобрать строку по каждому формату по очереди.
+func (p dateParser) parse(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range p.layouts {
+		if t, err := time.ParseInLocation(layout, s, p.loc); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// SortByDate выполняет хронологическую сортировку (флаг --date-sort).
+// По умолчанию распознает RFC 3339/ISO 8601, форматы можно задать через --date-format
+// в нотации пакета time. Строки, не являющиеся датами, сортируются лексикографически
+// и идут первыми, как и при сортировке по месяцам.
+//
+// Примеры:
+//
+//	"2026-03-01\n2025-12-31\n2026-01-15" → "2025-12-31\n2026-01-15\n2026-03-01"
+//	"2026-01-01T03:00:00+03:00\n2025-12-31T23:00:00Z" → сравнение в UTC, первой идет вторая строка
+//	"n/a\n2026-01-01" → "n/a\n2026-01-01" (не-даты первыми)
+func SortByDate(s string, modify func(string) string, opts domain.SortOptions) string {
+	parser, _ := newDateParser(opts) // зона проверяется в Sort
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareDateStrings(modify(rows[i]), modify(rows[j]), parser)
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareDateStrings сравнивает две строки по правилам хронологической сортировки.
+//
+// Примеры порядка:
+//
+//	"abc" < "xyz" < "2025-12-31" < "2026-01-01T00:00:00Z" < "2026-01-01T01:00:00Z"
+func compareDateStrings(iStr, jStr string, parser dateParser) bool {
+	iTime, iOk := parser.parse(iStr)
+	jTime, jOk := parser.parse(jStr)
+
+	switch {
+	case iOk && jOk:
+		return iTime.Before(jTime)
+	case iOk && !jOk:
+		return false
+	case !iOk && jOk:
+		return true
+	default:
+		return iStr < jStr
+	}
+}
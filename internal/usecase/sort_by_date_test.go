@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByDate(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "iso dates",
+			input:    "2026-03-01\n2025-12-31\n2026-01-15",
+			expected: "2025-12-31\n2026-01-15\n2026-03-01",
+		},
+		{
+			name:     "rfc3339 with offsets normalised to utc",
+			input:    "2025-12-31T23:30:00Z\n2026-01-01T01:00:00+03:00",
+			expected: "2026-01-01T01:00:00+03:00\n2025-12-31T23:30:00Z",
+		},
+		{
+			name:     "fractional seconds",
+			input:    "2026-01-01T00:00:00.5Z\n2026-01-01T00:00:00.25Z",
+			expected: "2026-01-01T00:00:00.25Z\n2026-01-01T00:00:00.5Z",
+		},
+		{
+			name:     "space separated date and time",
+			input:    "2026-01-01 12:00:00\n2026-01-01 09:30:00",
+			expected: "2026-01-01 09:30:00\n2026-01-01 12:00:00",
+		},
+		{
+			name:     "non-dates first",
+			input:    "2026-01-01\nn/a\n2025-01-01\nabc",
+			expected: "abc\nn/a\n2025-01-01\n2026-01-01",
+		},
+		{
+			name:     "custom layout",
+			input:    "02/01/2026 15:04\n31/12/2025 10:00\n01/01/2026 08:00",
+			opts:     domain.SortOptions{DateFormats: []string{"02/01/2006 15:04"}},
+			expected: "31/12/2025 10:00\n01/01/2026 08:00\n02/01/2026 15:04",
+		},
+		{
+			name:  "several custom layouts",
+			input: "Jan 2 2026\n2026.01.01",
+			opts: domain.SortOptions{
+				DateFormats: []string{"Jan 2 2006", "2006.01.02"},
+			},
+			expected: "2026.01.01\nJan 2 2026",
+		},
+		{
+			name:     "timezone for zone-less values",
+			input:    "2026-01-01T02:00:00\n2025-12-31T23:30:00Z",
+			opts:     domain.SortOptions{Timezone: "Europe/Moscow"},
+			expected: "2026-01-01T02:00:00\n2025-12-31T23:30:00Z",
+		},
+		{
+			name:     "fixed offset timezone",
+			input:    "2026-01-01T02:00:00\n2025-12-31T23:30:00Z",
+			opts:     domain.SortOptions{Timezone: "+03:00"},
+			expected: "2026-01-01T02:00:00\n2025-12-31T23:30:00Z",
+		},
+		{
+			name:     "equal dates keep input order",
+			input:    "2026-01-01T03:00:00+03:00\n2026-01-01T00:00:00Z",
+			expected: "2026-01-01T03:00:00+03:00\n2026-01-01T00:00:00Z",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByDate(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareDateStrings(t *testing.T) {
+	parser, err := newDateParser(domain.SortOptions{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "earlier before later",
+			a:        "2025-01-01",
+			b:        "2026-01-01",
+			expected: true,
+		},
+		{
+			name:     "later after earlier",
+			a:        "2026-01-01T00:00:01Z",
+			b:        "2026-01-01T00:00:00Z",
+			expected: false,
+		},
+		{
+			name:     "non-date before date",
+			a:        "abc",
+			b:        "2026-01-01",
+			expected: true,
+		},
+		{
+			name:     "date after non-date",
+			a:        "2026-01-01",
+			b:        "abc",
+			expected: false,
+		},
+		{
+			name:     "non-date < non-date",
+			a:        "abc",
+			b:        "xyz",
+			expected: true,
+		},
+		{
+			name:     "same instant in different zones",
+			a:        "2026-01-01T03:00:00+03:00",
+			b:        "2026-01-01T00:00:00Z",
+			expected: false,
+		},
+		{
+			name:     "surrounding blanks ignored",
+			a:        " 2025-01-01 ",
+			b:        "2026-01-01",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareDateStrings(tt.a, tt.b, parser)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestNewDateParserTimezone(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		wantErr  bool
+	}{
+		{name: "default utc", timezone: ""},
+		{name: "iana name", timezone: "Europe/Moscow"},
+		{name: "fixed offset with colon", timezone: "+03:00"},
+		{name: "fixed offset without colon", timezone: "-0700"},
+		{name: "unknown zone", timezone: "Mars/Olympus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := newDateParser(domain.SortOptions{Timezone: tt.timezone})
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidTimezone)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unix_sort_lite/internal/domain"
+)
+
+// SortByDomain выполняет сортировку доменных имен по меткам справа налево (флаг --domain-sort).
+// Имя разбивается на метки и сравнивается начиная с домена верхнего уровня,
+// поэтому поддомены одного сайта идут рядом сразу после самого домена.
+// Регистр и завершающая точка не учитываются. Строки, не являющиеся доменными именами,
+// сортируются лексикографически и идут последними.
+//
+// Примеры:
+//
+//	"www.example.com\napi.example.org\nexample.com" → "example.com\nwww.example.com\napi.example.org"
+//	"b.example.com\nA.example.com." → "A.example.com.\nb.example.com"
+func SortByDomain(s string, modify func(string) string, opts domain.SortOptions) string {
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareDomainStrings(modify(rows[i]), modify(rows[j]))
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareDomainStrings сравнивает две строки по правилам сортировки доменных имен.
+//
+// Примеры порядка:
+//
+//	example.com < api.example.com < www.example.com < example.org < "not a domain"
+func compareDomainStrings(iStr, jStr string) bool {
+	iLabels, iOk := domainLabels(iStr)
+	jLabels, jOk := domainLabels(jStr)
+
+	switch {
+	case iOk && jOk:
+		return slices.Compare(iLabels, jLabels) < 0
+	case iOk && !jOk:
+		return true
+	case !iOk && jOk:
+		return false
+	default:
+		return iStr < jStr
+	}
+}
+
+// domainLabels возвращает метки доменного имени в обратном порядке (TLD первым)
+// в нижнем регистре: "WWW.Example.com." → ["com", "example", "www"].
+// Имя некорректно, если содержит пустые метки или символы, кроме букв, цифр, '-' и '_',
+// или состоит из одной метки (кроме "localhost"): отдельное слово не считается доменом.
+func domainLabels(s string) ([]string, bool) {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ".")
+	if s == "" {
+		return nil, false
+	}
+
+	labels := strings.Split(s, ".")
+	if len(labels) < 2 && s != "localhost" {
+		return nil, false
+	}
+	for _, label := range labels {
+		if label == "" || strings.IndexFunc(label, isNotDomainRune) >= 0 {
+			return nil, false
+		}
+	}
+	slices.Reverse(labels)
+	return labels, true
+}
+
+// isNotDomainRune сообщает, может ли символ встречаться в метке доменного имени.
+// Буквы Unicode допускаются для интернационализированных имен.
+func isNotDomainRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+}
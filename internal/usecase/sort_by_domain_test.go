@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByDomain(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "group by registered domain",
+			input:    "www.example.com\napi.example.org\nexample.com\napi.example.com",
+			expected: "example.com\napi.example.com\nwww.example.com\napi.example.org",
+		},
+		{
+			name:     "tld first",
+			input:    "a.ru\nb.com\nc.org",
+			expected: "b.com\nc.org\na.ru",
+		},
+		{
+			name:     "case and trailing dot ignored",
+			input:    "b.example.com\nA.Example.COM.",
+			expected: "A.Example.COM.\nb.example.com",
+		},
+		{
+			name:     "invalid names last",
+			input:    "not a domain\nexample.com\na..b\n",
+			expected: "example.com\n\na..b\nnot a domain",
+		},
+		{
+			name:     "single word is not a domain",
+			input:    "hello\nlocalhost\nexample.com",
+			expected: "example.com\nlocalhost\nhello",
+		},
+		{
+			name:     "internationalized names",
+			input:    "почта.пример.рф\nпример.рф",
+			expected: "пример.рф\nпочта.пример.рф",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByDomain(tt.input, identity, domain.SortOptions{})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareDomainStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "parent before subdomain",
+			a:        "example.com",
+			b:        "api.example.com",
+			expected: true,
+		},
+		{
+			name:     "subdomain after sibling domain of other tld",
+			a:        "www.example.org",
+			b:        "example.com",
+			expected: false,
+		},
+		{
+			name:     "domain before invalid",
+			a:        "example.com",
+			b:        "http://example.com",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareDomainStrings(tt.a, tt.b)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
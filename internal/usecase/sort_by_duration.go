@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unix_sort_lite/internal/domain"
+)
+
+// durationUnits — единицы длительности; многосимвольные проверяются раньше "m" и "s".
+var durationUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"ns", time.Nanosecond},
+	{"us", time.Microsecond},
+	{"µs", time.Microsecond},
+	{"μs", time.Microsecond},
+	{"ms", time.Millisecond},
+	{"s", time.Second},
+	{"m", time.Minute},
+	{"h", time.Hour},
+	{"d", 24 * time.Hour},
+}
+
+// SortByDuration выполняет сортировку по длительности (флаг --duration-sort).
+// Распознает длительности в формате Go ("12ms", "1h30m", "1.5s", "250µs") и дни ("2d").
+// Строки, не являющиеся длительностью, сортируются лексикографически и идут после них.
+//
+// Примеры:
+//
+//	"1s\n900ms\n1m" → "900ms\n1s\n1m"
+//	"2d\n47h\nabc" → "47h\n2d\nabc"
+func SortByDuration(s string, modify func(string) string, opts domain.SortOptions) string {
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareDurationStrings(modify(rows[i]), modify(rows[j]))
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareDurationStrings сравнивает две строки по длительности.
+//
+// Примеры правильного порядка:
+//
+//	-1s < 0 < 500us < 12ms < 1.5s < 1m < 1h30m < 2d < abc
+func compareDurationStrings(iStr, jStr string) bool {
+	iDur, iOk := parseDuration(iStr)
+	jDur, jOk := parseDuration(jStr)
+
+	switch {
+	case iOk && jOk:
+		return iDur < jDur
+	case iOk != jOk:
+		return iOk
+	default:
+		return iStr < jStr
+	}
+}
+
+// parseDuration разбирает длительность из последовательности чисел с единицами
+// и возвращает ее в наносекундах. Число без единицы допускается только для нуля.
+func parseDuration(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	sign := 1.0
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, true
+	}
+	if s == "" {
+		return 0, false
+	}
+
+	var total float64
+	for s != "" {
+		end := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if end <= 0 {
+			return 0, false
+		}
+		num, err := strconv.ParseFloat(s[:end], 64)
+		if err != nil {
+			return 0, false
+		}
+		s = s[end:]
+
+		matched := false
+		for _, u := range durationUnits {
+			if strings.HasPrefix(s, u.name) {
+				total += num * float64(u.unit)
+				s = s[len(u.name):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	return sign * total, true
+}
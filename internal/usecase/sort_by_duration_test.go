@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByDuration(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "different units",
+			input:    "1s\n900ms\n1m\n250µs",
+			expected: "250µs\n900ms\n1s\n1m",
+		},
+		{
+			name:     "compound durations and days",
+			input:    "2d\n1h30m\n47h\n90m",
+			expected: "1h30m\n90m\n47h\n2d",
+		},
+		{
+			name:     "fractions, zero and negative",
+			input:    "1.5s\n0\n-1s\n1s",
+			expected: "-1s\n0\n1s\n1.5s",
+		},
+		{
+			name:     "non-durations last",
+			input:    "xyz\n10\n5ms\nabc",
+			expected: "5ms\n10\nabc\nxyz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByDuration(tt.input, identity, domain.SortOptions{Duration: true})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
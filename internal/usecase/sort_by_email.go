@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// SortByEmail выполняет сортировку адресов электронной почты (флаг --email-sort).
+// Адреса сравниваются сначала по домену (как в SortByDomain, TLD первым),
+// затем по локальной части без учета регистра. Поддерживается запись
+// "Имя <user@example.com>". Строки, не являющиеся адресами, идут последними.
+//
+// Примеры:
+//
+//	"bob@b.org\nalice@b.org\nzed@a.org" → "zed@a.org\nalice@b.org\nbob@b.org"
+//	"Bob <bob@mail.example.com>\nann@example.com" → "ann@example.com\nBob <bob@mail.example.com>"
+func SortByEmail(s string, modify func(string) string, opts domain.SortOptions) string {
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareEmailStrings(modify(rows[i]), modify(rows[j]))
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareEmailStrings сравнивает две строки по правилам сортировки адресов.
+//
+// Примеры порядка:
+//
+//	a@example.com < b@example.com < a@mail.example.com < a@example.org < "no address"
+func compareEmailStrings(iStr, jStr string) bool {
+	iLocal, iDomain, iOk := parseEmail(iStr)
+	jLocal, jDomain, jOk := parseEmail(jStr)
+
+	switch {
+	case iOk && jOk:
+		return cmp.Or(
+			slices.Compare(iDomain, jDomain),
+			strings.Compare(iLocal, jLocal),
+		) < 0
+	case iOk && !jOk:
+		return true
+	case !iOk && jOk:
+		return false
+	default:
+		return iStr < jStr
+	}
+}
+
+// parseEmail разбирает адрес на локальную часть в нижнем регистре и метки домена
+// в обратном порядке. Домен отделяется по последнему '@'.
+func parseEmail(s string) (string, []string, bool) {
+	s = strings.TrimSpace(s)
+	if start, end := strings.LastIndex(s, "<"), strings.LastIndex(s, ">"); start >= 0 && end > start {
+		s = s[start+1 : end]
+	}
+
+	at := strings.LastIndex(s, "@")
+	if at < 1 || strings.ContainsAny(s[:at], " \t") {
+		return "", nil, false
+	}
+	labels, ok := domainLabels(s[at+1:])
+	if !ok {
+		return "", nil, false
+	}
+	return strings.ToLower(s[:at]), labels, true
+}
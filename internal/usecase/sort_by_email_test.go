@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByEmail(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "domain then local part",
+			input:    "bob@b.org\nalice@b.org\nzed@a.org",
+			expected: "zed@a.org\nalice@b.org\nbob@b.org",
+		},
+		{
+			name:     "subdomains grouped with domain",
+			input:    "x@mail.example.com\ny@example.org\nz@example.com",
+			expected: "z@example.com\nx@mail.example.com\ny@example.org",
+		},
+		{
+			name:     "case insensitive",
+			input:    "Bob@Example.com\nalice@example.COM",
+			expected: "alice@example.COM\nBob@Example.com",
+		},
+		{
+			name:     "display name form",
+			input:    "Zed <a@example.org>\nAnn <b@example.com>",
+			expected: "Ann <b@example.com>\nZed <a@example.org>",
+		},
+		{
+			name:     "invalid addresses last",
+			input:    "no address\n@example.com\nann@example.com\nann@",
+			expected: "ann@example.com\n@example.com\nann@\nno address",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByEmail(tt.input, identity, domain.SortOptions{})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareEmailStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "same domain by local part",
+			a:        "a@example.com",
+			b:        "b@example.com",
+			expected: true,
+		},
+		{
+			name:     "domain dominates local part",
+			a:        "a@example.org",
+			b:        "z@example.com",
+			expected: false,
+		},
+		{
+			name:     "last at sign separates domain",
+			a:        `"a@b"@example.com`,
+			b:        "a@example.org",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareEmailStrings(tt.a, tt.b)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -15,6 +15,8 @@ type rowData struct {
 // SortByField выполняет сортировку по указанному полю (флаг -k в Unix sort).
 // Поддерживает различные типы интерпретации поля: числовая, месячная, human-readable.
 // Строки без достаточного количества полей сортируются по количеству полей.
+// Поля разделяются пробельными символами или разделителем из флага -t.
+// При равенстве полей строки можно упорядочить по метрике строки (флаг --tiebreak-metric).
 
 // Примеры:
 //
@@ -23,12 +25,13 @@ type rowData struct {
 func SortByField(s string, modify func(string) string, opts domain.SortOptions) string {
 	N := opts.Field
 	lines := strings.Split(s, "\n")
+	less := keyComparator(opts)
 
 	// Создаем массив структур для хранения полей и оригинальных строк
 	rows := make([]rowData, len(lines))
 	for i, line := range lines {
 		rows[i] = rowData{
-			fields:   strings.Fields(line),
+			fields:   lineFields(line, opts),
 			original: line,
 		}
 	}
@@ -42,27 +45,112 @@ func SortByField(s string, modify func(string) string, opts domain.SortOptions)
 		iField := modify(rows[i].fields[N-1])
 		jField := modify(rows[j].fields[N-1])
 
+		if less(iField, jField) {
+			return true
+		}
+		if opts.Tiebreak == "" || less(jField, iField) {
+			return false
+		}
+		// Поля равны: сравнение строк целиком по метрике (флаг --tiebreak-metric)
+		return lineMetric(modify(rows[i].original), opts.Tiebreak) < lineMetric(modify(rows[j].original), opts.Tiebreak)
+	})
+
+	resLines := make([]string, len(rows))
+	for i, row := range rows {
+		resLines[i] = row.original
+	}
+
+	return strings.Join(resLines, "\n")
+}
+
+// lineFields разбивает строку на поля по разделителю -t или на колонки фиксированной
+// ширины --columns. С флагом --strip-ansi escape-последовательности удаляются до разбиения,
+// чтобы ";" и цифры из кодов цвета не порождали лишних полей и не сдвигали колонки.
+func lineFields(line string, opts domain.SortOptions) []string {
+	if opts.StripANSI {
+		line = stripANSI(line)
+	}
+	if opts.Columns != "" {
+		columns, _ := parseColumns(opts.Columns) // колонки проверяются в Sort
+		return splitColumns(line, columns)
+	}
+	return splitFields(line, opts.Separator)
+}
+
+// splitFields разбивает строку на поля.
+// Без разделителя поля отделяются пробельными символами, как в strings.Fields.
+// С разделителем (флаг -t) каждое вхождение разделителя начинает новое поле,
+// поэтому пустые поля сохраняются: "a,,c" → ["a", "", "c"].
+func splitFields(line, sep string) []string {
+	if sep == "" {
+		return strings.Fields(line)
+	}
+	if line == "" {
+		return nil
+	}
+	return strings.Split(line, sep)
+}
+
+// keyComparator возвращает функцию сравнения ключей для типа сортировки из opts:
+// -n, -M, -h, --date-sort, --ip-sort и другие. Без типа ключи сравниваются
+// по правилам локали (--locale) или лексикографически без учета регистра.
+// Используется для полей -k и ключей, извлеченных --key-regex.
+func keyComparator(opts domain.SortOptions) func(iKey, jKey string) bool {
+	dates, _ := newDateParser(opts) // зона проверяется в Sort
+	months := calendarNames(monthNames, opts.MonthLocale)
+	weekdays := calendarNames(weekdayNames, opts.MonthLocale)
+	order, _ := newCustomOrder(opts) // размещение проверяется в Sort
+	paths := pathOrder{dirsFirst: opts.DirsFirst, version: opts.PathVersion}
+	coll, _ := newCollation(opts) // локаль проверяется в Sort
+
+	return func(iField, jField string) bool {
 		switch {
 		case opts.Numeric:
 			// Числовое сравнение полей (флаг -n)
 			return compareNumericStrings(iField, jField)
 		case opts.Month:
 			// Сравнение по месяцам (флаг -M)
-			return compareMonthStrings(iField, jField)
+			return compareMonthStrings(iField, jField, months)
+		case opts.Weekday:
+			// Сравнение по дням недели (флаг --weekday-sort)
+			return compareWeekdayStrings(iField, jField, weekdays)
 		case opts.HumanNumeric:
 			// Human-readable числовое сравнение (флаг -h)
 			return compareHumanNumericStrings(iField, jField)
+		case opts.Date:
+			// Хронологическое сравнение (флаг --date-sort)
+			return compareDateStrings(iField, jField, dates)
+		case opts.Duration:
+			// Сравнение длительностей (флаг --duration-sort)
+			return compareDurationStrings(iField, jField)
+		case opts.IP:
+			// Сравнение IP-адресов и префиксов CIDR (флаг --ip-sort)
+			return compareIPStrings(iField, jField)
+		case opts.Domain:
+			// Сравнение доменных имен справа налево (флаг --domain-sort)
+			return compareDomainStrings(iField, jField)
+		case opts.Email:
+			// Сравнение адресов по домену и локальной части (флаг --email-sort)
+			return compareEmailStrings(iField, jField)
+		case opts.URL:
+			// Сравнение URL по хосту, пути и параметрам (флаг --url-sort)
+			return compareURLStrings(iField, jField)
+		case opts.Path:
+			// Сравнение путей по компонентам (флаг --path-sort)
+			return comparePathStrings(iField, jField, paths)
+		case opts.Metric != "":
+			// Сравнение по метрике строки (флаг --metric)
+			return compareMetricStrings(iField, jField, opts.Metric)
+		case len(opts.Order) > 0:
+			// Сравнение по пользовательскому списку (флаги --order, --order-file)
+			return compareOrderStrings(iField, jField, order)
+		case coll != nil:
+			// Сравнение по правилам локали (флаги --locale, --case-first)
+			return coll.less(iField, jField)
 		default:
 			// Лексикографическое сравнение (по умолчанию)
 			// Используем ToLower для регистронезависимого сравнения
 			return strings.ToLower(iField) < strings.ToLower(jField)
 		}
-	})
-
-	resLines := make([]string, len(rows))
-	for i, row := range rows {
-		resLines[i] = row.original
 	}
-
-	return strings.Join(resLines, "\n")
 }
@@ -58,6 +58,48 @@ func TestSortByField(t *testing.T) {
 			opts:     domain.SortOptions{Field: 2, Month: true},
 			expected: "party Jan\nevent Feb\nmeeting Mar",
 		},
+		{
+			name:     "localized month field sort",
+			input:    "b 18 марта\na 1 января",
+			opts:     domain.SortOptions{Field: 3, Month: true, MonthLocale: "ru"},
+			expected: "a 1 января\nb 18 марта",
+		},
+		{
+			name:     "weekday field sort",
+			input:    "standup Fri\nplanning Mon",
+			opts:     domain.SortOptions{Field: 2, Weekday: true},
+			expected: "planning Mon\nstandup Fri",
+		},
+		{
+			name:     "custom order field sort",
+			input:    "task-1 high\ntask-2 low\ntask-3 critical\ntask-4 medium",
+			opts:     domain.SortOptions{Field: 2, Order: []string{"low", "medium", "high", "critical"}},
+			expected: "task-2 low\ntask-4 medium\ntask-1 high\ntask-3 critical",
+		},
+		{
+			name:     "ip field sort",
+			input:    "allow 10.0.0.10\ndeny 10.0.0.2\nallow 10.0.0.0/8",
+			opts:     domain.SortOptions{Field: 2, IP: true},
+			expected: "allow 10.0.0.0/8\ndeny 10.0.0.2\nallow 10.0.0.10",
+		},
+		{
+			name:     "domain field sort",
+			input:    "1 www.example.com\n2 example.org\n3 example.com",
+			opts:     domain.SortOptions{Field: 2, Domain: true},
+			expected: "3 example.com\n1 www.example.com\n2 example.org",
+		},
+		{
+			name:     "metric field sort",
+			input:    "x ccc\ny a\nz bb",
+			opts:     domain.SortOptions{Field: 2, Metric: "runes"},
+			expected: "y a\nz bb\nx ccc",
+		},
+		{
+			name:     "metric tiebreak on equal fields",
+			input:    "ERROR a very long message\nINFO ok\nERROR short",
+			opts:     domain.SortOptions{Field: 1, Tiebreak: "bytes"},
+			expected: "ERROR short\nERROR a very long message\nINFO ok",
+		},
 		{
 			name:     "human numeric field sort",
 			input:    "file 1M\ndata 2K\nlog 500",
@@ -88,6 +130,35 @@ func TestSortByField(t *testing.T) {
 			opts:     domain.SortOptions{Field: 2},
 			expected: "a   b\nc d\ne    f   g",
 		},
+		{
+			name:     "date field sort",
+			input:    "b 2026-02-01\na 2025-12-31\nc 2026-01-15",
+			opts:     domain.SortOptions{Field: 2, Date: true},
+			expected: "a 2025-12-31\nc 2026-01-15\nb 2026-02-01",
+		},
+		{
+			name:  "csv column with custom date layout",
+			input: "1,alice,ok,02/01/2026 15:04\n2,bob,ok,31/12/2025 10:00\n3,eve,fail,01/01/2026 08:00",
+			opts: domain.SortOptions{
+				Field:       4,
+				Date:        true,
+				Separator:   ",",
+				DateFormats: []string{"02/01/2006 15:04"},
+			},
+			expected: "2,bob,ok,31/12/2025 10:00\n3,eve,fail,01/01/2026 08:00\n1,alice,ok,02/01/2026 15:04",
+		},
+		{
+			name:     "strip ansi before splitting",
+			input:    "\x1b[1;31mb;2\x1b[0m\n\x1b[32ma;1\x1b[0m",
+			opts:     domain.SortOptions{Field: 2, Separator: ";", StripANSI: true},
+			expected: "\x1b[32ma;1\x1b[0m\n\x1b[1;31mb;2\x1b[0m",
+		},
+		{
+			name:     "custom separator keeps empty fields",
+			input:    "x,,b\ny,,a",
+			opts:     domain.SortOptions{Field: 3, Separator: ","},
+			expected: "y,,a\nx,,b",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// earthRadiusKm — средний радиус Земли (IUGG), используется в формуле гаверсинусов.
+const earthRadiusKm = 6371.0088
+
+// geoPoint представляет координаты в десятичных градусах.
+type geoPoint struct {
+	lat, lon float64
+}
+
+// geoRow хранит строку и расстояние от опорной точки до ее координат.
+type geoRow struct {
+	line     string
+	distance float64
+	valid    bool
+}
+
+// parseNear разбирает опорную точку из флага --near в формате "LAT,LON".
+func parseNear(near string) (geoPoint, error) {
+	point, ok := parseGeoPoint(near)
+	if !ok {
+		return geoPoint{}, fmt.Errorf("%w: %q", domain.ErrInvalidCoordinates, near)
+	}
+	return point, nil
+}
+
+// validateGeoFields проверяет поля координат --geo-fields: одно или два поля с номерами от 1.
+func validateGeoFields(fields []int) error {
+	if len(fields) > 2 {
+		return fmt.Errorf("%w: %v", domain.ErrInvalideField, fields)
+	}
+	for _, field := range fields {
+		if field < 1 {
+			return fmt.Errorf("%w: %v", domain.ErrInvalideField, fields)
+		}
+	}
+	return nil
+}
+
+// SortByGeo выполняет сортировку по расстоянию до опорной точки (флаг --near LAT,LON).
+// Расстояние считается по большому кругу (формула гаверсинусов). Координаты
+// в десятичных градусах берутся из строки по правилам:
+//   - --geo-fields LAT,LON: широта и долгота в двух разных полях;
+//   - --geo-fields N или -k N: поле N содержит "lat,lon";
+//   - иначе вся строка содержит "lat,lon" или "lat lon".
+//
+// Строки с некорректными координатами (не числа, широта вне [-90, 90],
+// долгота вне [-180, 180]) идут последними в исходном порядке, в том числе с -r:
+// флаг -r меняет только порядок расстояний.
+//
+// Примеры (--near 55.7558,37.6173, Москва):
+//
+//	"59.9343,30.3351\n55.7963,37.9382" → "55.7963,37.9382\n59.9343,30.3351"
+//	"spb 59.93 30.33\nmsk 55.79 37.93" с --geo-fields 2,3 → "msk 55.79 37.93\nspb 59.93 30.33"
+func SortByGeo(s string, modify func(string) string, opts domain.SortOptions) string {
+	origin, _ := parseNear(opts.Near) // опорная точка проверяется в Sort
+	lines := strings.Split(s, "\n")
+
+	rows := make([]geoRow, len(lines))
+	for i, line := range lines {
+		rows[i] = geoRow{line: line}
+		if point, ok := geoPointFromLine(line, modify, opts); ok {
+			rows[i].distance, rows[i].valid = haversine(origin, point), true
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch {
+		case rows[i].valid && rows[j].valid:
+			if opts.Reverse {
+				return rows[i].distance > rows[j].distance
+			}
+			return rows[i].distance < rows[j].distance
+		default:
+			return rows[i].valid && !rows[j].valid
+		}
+	})
+
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i] = row.line
+	}
+	return strings.Join(result, "\n")
+}
+
+// geoPointFromLine извлекает координаты строки согласно --geo-fields и -k.
+func geoPointFromLine(line string, modify func(string) string, opts domain.SortOptions) (geoPoint, bool) {
+	fields := lineFields(line, opts)
+	field := func(n int) (string, bool) {
+		if n < 1 || n > len(fields) {
+			return "", false
+		}
+		return modify(fields[n-1]), true
+	}
+
+	switch {
+	case len(opts.GeoFields) >= 2:
+		lat, latOk := field(opts.GeoFields[0])
+		lon, lonOk := field(opts.GeoFields[1])
+		if !latOk || !lonOk {
+			return geoPoint{}, false
+		}
+		return parseGeoPoint(lat + "," + lon)
+	case len(opts.GeoFields) == 1:
+		value, ok := field(opts.GeoFields[0])
+		if !ok {
+			return geoPoint{}, false
+		}
+		return parseGeoPoint(value)
+	case opts.Key:
+		value, ok := field(opts.Field)
+		if !ok {
+			return geoPoint{}, false
+		}
+		return parseGeoPoint(value)
+	default:
+		return parseGeoPoint(modify(line))
+	}
+}
+
+// parseGeoPoint разбирает "lat,lon" или "lat lon" в десятичных градусах
+// и проверяет допустимые диапазоны.
+func parseGeoPoint(s string) (geoPoint, bool) {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	if len(parts) != 2 {
+		return geoPoint{}, false
+	}
+
+	lat, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return geoPoint{}, false
+	}
+	lon, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return geoPoint{}, false
+	}
+	return geoPoint{lat: lat, lon: lon}, true
+}
+
+// haversine вычисляет расстояние по большому кругу между двумя точками в километрах.
+func haversine(a, b geoPoint) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(b.lat - a.lat)
+	dLon := toRad(b.lon - a.lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.lat))*math.Cos(toRad(b.lat))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
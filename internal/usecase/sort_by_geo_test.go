@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByGeo(t *testing.T) {
+	identity := func(s string) string { return s }
+	moscow := "55.7558,37.6173"
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "whole line lat,lon",
+			input:    "59.9343,30.3351\n55.7963,37.9382\n43.1155,131.8855",
+			opts:     domain.SortOptions{Near: moscow},
+			expected: "55.7963,37.9382\n59.9343,30.3351\n43.1155,131.8855",
+		},
+		{
+			name:     "whole line lat lon separated by space",
+			input:    "59.9343 30.3351\n55.7963 37.9382",
+			opts:     domain.SortOptions{Near: moscow},
+			expected: "55.7963 37.9382\n59.9343 30.3351",
+		},
+		{
+			name:     "two csv fields",
+			input:    "spb,59.9343,30.3351\nbalashikha,55.7963,37.9382\nvladivostok,43.1155,131.8855",
+			opts:     domain.SortOptions{Near: moscow, Separator: ",", GeoFields: []int{2, 3}},
+			expected: "balashikha,55.7963,37.9382\nspb,59.9343,30.3351\nvladivostok,43.1155,131.8855",
+		},
+		{
+			name:     "one field with lat,lon",
+			input:    "spb 59.9343,30.3351\nbalashikha 55.7963,37.9382",
+			opts:     domain.SortOptions{Near: moscow, GeoFields: []int{2}},
+			expected: "balashikha 55.7963,37.9382\nspb 59.9343,30.3351",
+		},
+		{
+			name:     "key field with lat,lon",
+			input:    "spb 59.9343,30.3351\nbalashikha 55.7963,37.9382",
+			opts:     domain.SortOptions{Near: moscow, Key: true, Field: 2},
+			expected: "balashikha 55.7963,37.9382\nspb 59.9343,30.3351",
+		},
+		{
+			name:     "antimeridian",
+			input:    "0,-170\n0,179",
+			opts:     domain.SortOptions{Near: "0,-179"},
+			expected: "0,179\n0,-170",
+		},
+		{
+			name:     "invalid coordinates last in input order",
+			input:    "n/a\n91,0\n55.7963,37.9382\n0,181\n",
+			opts:     domain.SortOptions{Near: moscow},
+			expected: "55.7963,37.9382\nn/a\n91,0\n0,181\n",
+		},
+		{
+			name:     "missing geo fields are invalid",
+			input:    "depot\nsite 55.7963 37.9382",
+			opts:     domain.SortOptions{Near: moscow, GeoFields: []int{2, 3}},
+			expected: "site 55.7963 37.9382\ndepot",
+		},
+		{
+			name:     "reverse keeps invalid coordinates last",
+			input:    "bad\n59.9343,30.3351\n55.7963,37.9382",
+			opts:     domain.SortOptions{Near: moscow, Reverse: true},
+			expected: "59.9343,30.3351\n55.7963,37.9382\nbad",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByGeo(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	// Sort не разворачивает результат повторно
+	result, err := Sort("bad\n59.9343,30.3351\n55.7963,37.9382", domain.SortOptions{Near: moscow, Reverse: true})
+	require.NoError(t, err)
+	require.Equal(t, "59.9343,30.3351\n55.7963,37.9382\nbad", result)
+}
+
+func TestHaversine(t *testing.T) {
+	moscow := geoPoint{lat: 55.7558, lon: 37.6173}
+	spb := geoPoint{lat: 59.9343, lon: 30.3351}
+
+	require.InDelta(t, 634, haversine(moscow, spb), 5)
+	require.InDelta(t, haversine(moscow, spb), haversine(spb, moscow), 1e-9)
+	require.Zero(t, haversine(moscow, moscow))
+}
+
+func TestParseNear(t *testing.T) {
+	tests := []struct {
+		name    string
+		near    string
+		wantErr bool
+	}{
+		{name: "comma", near: "55.75,37.61"},
+		{name: "comma and space", near: "55.75, 37.61"},
+		{name: "negative", near: "-33.86,151.2"},
+		{name: "latitude out of range", near: "95,0", wantErr: true},
+		{name: "longitude out of range", near: "0,-200", wantErr: true},
+		{name: "single value", near: "55.75", wantErr: true},
+		{name: "not a number", near: "north,east", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseNear(tt.near)
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidCoordinates)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSortGeoFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    domain.SortOptions
+		wantErr error
+	}{
+		{name: "lat and lon fields", opts: domain.SortOptions{Near: "0,0", GeoFields: []int{2, 3}}},
+		{name: "single field", opts: domain.SortOptions{Near: "0,0", GeoFields: []int{2}}},
+		{name: "zero field", opts: domain.SortOptions{Near: "0,0", GeoFields: []int{0, 1}}, wantErr: domain.ErrInvalideField},
+		{name: "negative field", opts: domain.SortOptions{Near: "0,0", GeoFields: []int{-1}}, wantErr: domain.ErrInvalideField},
+		{name: "too many fields", opts: domain.SortOptions{Near: "0,0", GeoFields: []int{1, 2, 3}}, wantErr: domain.ErrInvalideField},
+		{name: "without near", opts: domain.SortOptions{GeoFields: []int{2, 3}}, wantErr: domain.ErrConflictOpts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Sort("a 1 2\nb 3 4", tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"net/netip"
+	"sort"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// SortByIP выполняет сортировку по IP-адресам (флаг --ip-sort).
+// Распознает IPv4, IPv6 (в том числе сокращенную запись и зону "fe80::1%eth0")
+// и префиксы CIDR. IPv4 идут перед IPv6, затем адреса сравниваются численно,
+// а при равных адресах — по длине префикса (адрес без префикса считается
+// префиксом максимальной длины, /32 или /128).
+// Строки, не являющиеся адресами, сортируются лексикографически и идут после адресов.
+//
+// Примеры:
+//
+//	"10.0.0.10\n10.0.0.2" → "10.0.0.2\n10.0.0.10"
+//	"::1\n192.168.0.1" → "192.168.0.1\n::1" (IPv4 перед IPv6)
+//	"10.0.0.0/24\n10.0.0.0/8\nunknown" → "10.0.0.0/8\n10.0.0.0/24\nunknown"
+func SortByIP(s string, modify func(string) string, opts domain.SortOptions) string {
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareIPStrings(modify(rows[i]), modify(rows[j]))
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareIPStrings сравнивает две строки по правилам сортировки IP-адресов.
+//
+// Примеры порядка:
+//
+//	1.2.3.4 < 10.0.0.0/8 < 10.0.0.2 < 10.0.0.10 < ::1 < 2001:db8::/32 < abc
+func compareIPStrings(iStr, jStr string) bool {
+	iIP, iOk := parseIPKey(iStr)
+	jIP, jOk := parseIPKey(jStr)
+
+	switch {
+	case iOk && jOk:
+		if cmp := iIP.addr.Compare(jIP.addr); cmp != 0 {
+			return cmp < 0
+		}
+		return iIP.bits < jIP.bits
+	case iOk && !jOk:
+		return true
+	case !iOk && jOk:
+		return false
+	default:
+		return iStr < jStr
+	}
+}
+
+// ipKey представляет адрес с длиной префикса.
+// Зона IPv6 сохраняется в addr и учитывается netip.Addr.Compare.
+type ipKey struct {
+	addr netip.Addr
+	bits int
+}
+
+// parseIPKey разбирает адрес или префикс CIDR. Адрес без префикса
+// получает префикс полной длины. Адрес в префиксе не маскируется:
+// "10.0.0.1/8" сравнивается по 10.0.0.1.
+func parseIPKey(s string) (ipKey, bool) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return ipKey{}, false
+		}
+		return ipKey{addr: prefix.Addr(), bits: prefix.Bits()}, true
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return ipKey{}, false
+	}
+	return ipKey{addr: addr, bits: addr.BitLen()}, true
+}
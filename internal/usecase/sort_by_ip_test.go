@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByIP(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "ipv4 numeric order",
+			input:    "10.0.0.10\n10.0.0.2\n9.255.255.255",
+			expected: "9.255.255.255\n10.0.0.2\n10.0.0.10",
+		},
+		{
+			name:     "ipv4 before ipv6",
+			input:    "::1\n192.168.0.1\n2001:db8::1",
+			expected: "192.168.0.1\n::1\n2001:db8::1",
+		},
+		{
+			name:     "compressed and full ipv6 forms",
+			input:    "2001:db8:0:0:0:0:0:2\n2001:db8::1",
+			expected: "2001:db8::1\n2001:db8:0:0:0:0:0:2",
+		},
+		{
+			name:     "ipv6 zones",
+			input:    "fe80::1%eth1\nfe80::1%eth0\nfe80::1",
+			expected: "fe80::1\nfe80::1%eth0\nfe80::1%eth1",
+		},
+		{
+			name:     "cidr by address then prefix length",
+			input:    "10.0.0.0/24\n10.0.0.0\n10.0.0.0/8\n10.0.0.1/32",
+			expected: "10.0.0.0/8\n10.0.0.0/24\n10.0.0.0\n10.0.0.1/32",
+		},
+		{
+			name:     "invalid values last",
+			input:    "localhost\n10.0.0.1\n256.0.0.1\n::1",
+			expected: "10.0.0.1\n::1\n256.0.0.1\nlocalhost",
+		},
+		{
+			name:     "surrounding blanks ignored",
+			input:    " 10.0.0.10\n10.0.0.9 ",
+			expected: "10.0.0.9 \n 10.0.0.10",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByIP(tt.input, identity, domain.SortOptions{})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareIPStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "10.0.0.2 < 10.0.0.10",
+			a:        "10.0.0.2",
+			b:        "10.0.0.10",
+			expected: true,
+		},
+		{
+			name:     "ipv6 after ipv4",
+			a:        "::ffff:1.2.3.4",
+			b:        "255.255.255.255",
+			expected: false,
+		},
+		{
+			name:     "shorter prefix first",
+			a:        "192.168.0.0/16",
+			b:        "192.168.0.0/24",
+			expected: true,
+		},
+		{
+			name:     "address before invalid",
+			a:        "::1",
+			b:        "abc",
+			expected: true,
+		},
+		{
+			name:     "invalid prefix is not an address",
+			a:        "10.0.0.0/33",
+			b:        "10.0.0.0",
+			expected: false,
+		},
+		{
+			name:     "invalid < invalid lexicographically",
+			a:        "abc",
+			b:        "xyz",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareIPStrings(tt.a, tt.b)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
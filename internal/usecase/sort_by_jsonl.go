@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// Виды значений JSON в порядке сортировки ключей без типа
+const (
+	jsonMissing   = iota // null или поле отсутствует
+	jsonNumber           // число
+	jsonString           // строка
+	jsonBool             // false < true
+	jsonComposite        // объект или массив, сравнивается по компактной записи
+)
+
+// jsonValue — значение ключа, извлеченное из строки JSON.
+type jsonValue struct {
+	kind int
+	num  float64
+	text string // строка, запись числа, "true"/"false" или компактный JSON
+}
+
+// jsonRow хранит строку JSON Lines и значения ее ключей.
+type jsonRow struct {
+	record
+	values []jsonValue
+}
+
+// parseJSONPath разбирает путь к значению: JSON Pointer по RFC 6901 ("/request/duration_ms",
+// "/items/0", "~1" и "~0" обозначают "/" и "~") или путь в стиле jq (".request.duration_ms",
+// ".items[0].name"). Возвращает имена полей и индексы массивов по порядку.
+func parseJSONPath(path string) ([]string, error) {
+	invalid := fmt.Errorf("%w: %q", domain.ErrInvalidJSONPath, path)
+
+	switch {
+	case strings.HasPrefix(path, "/"):
+		tokens := strings.Split(path[1:], "/")
+		for i, token := range tokens {
+			tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		}
+		return tokens, nil
+	case path == ".":
+		return nil, nil
+	case strings.HasPrefix(path, "."), strings.HasPrefix(path, "["):
+		var tokens []string
+		for rest := path; rest != ""; {
+			switch rest[0] {
+			case '.':
+				end := strings.IndexAny(rest[1:], ".[")
+				if end < 0 {
+					end = len(rest) - 1
+				}
+				if end == 0 {
+					return nil, invalid
+				}
+				tokens = append(tokens, rest[1:end+1])
+				rest = rest[end+1:]
+			case '[':
+				end := strings.IndexByte(rest, ']')
+				if end < 0 {
+					return nil, invalid
+				}
+				if _, err := strconv.Atoi(rest[1:end]); err != nil {
+					return nil, invalid
+				}
+				tokens = append(tokens, rest[1:end])
+				rest = rest[end+1:]
+			default:
+				return nil, invalid
+			}
+		}
+		return tokens, nil
+	default:
+		return nil, invalid
+	}
+}
+
+// SortByJSONL выполняет сортировку JSON Lines (флаг --jsonl) по ключам -k, заданным путями.
+// Ключ с типом (":n", ":date", ...) сравнивается как поле -k этого типа. Ключ без типа
+// сравнивается по типу значения JSON: числа численно, строки как текст, false < true,
+// объекты и массивы по компактной записи; значения разных типов — в порядке
+// число < строка < логическое < объект/массив. Null, отсутствующие поля и строки,
+// не являющиеся JSON, по умолчанию идут последними, --json-null=first переносит их в начало;
+// -r меняет порядок значений, но не размещение null.
+// Строки выводятся без изменений.
+//
+// Примеры:
+//
+//	`{"ms":120}` и `{"ms":15}` с -k /ms → сначала `{"ms":15}`
+//	`{"ts":null}` и `{"ts":"2026-01-01"}` с -k .ts → null последним
+func SortByJSONL(s string, modify func(string) string, opts domain.SortOptions) string {
+	nullsFirst := opts.JSONNull == domain.PlaceFirst
+	comparators := make([]func(iKey, jKey string) bool, len(opts.Keys))
+	paths := make([][]string, len(opts.Keys))
+	for k, key := range opts.Keys {
+		comparators[k] = keyComparator(keyTypeOptions(opts, key.Type))
+		paths[k], _ = parseJSONPath(key.Path) // пути проверяются в Sort
+	}
+
+	lines := strings.Split(s, "\n")
+	rows := make([]jsonRow, len(lines))
+	for i, line := range lines {
+		doc, ok := decodeJSONLine(line)
+		row := jsonRow{
+			record: record{raw: line, fields: make([]string, len(paths))},
+			values: make([]jsonValue, len(paths)),
+		}
+		for k, path := range paths {
+			if ok {
+				row.values[k] = lookupJSON(doc, path)
+				row.values[k].text = modify(row.values[k].text)
+			}
+			row.fields[k] = row.values[k].text
+		}
+		rows[i] = row
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, key := range opts.Keys {
+			cmp := compareJSONValues(rows[i].values[k], rows[j].values[k], key.Type != "", comparators[k], nullsFirst, opts.Reverse)
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+
+	records := make([]record, len(rows))
+	for i, row := range rows {
+		records[i] = row.record
+	}
+	// Поля записей — значения ключей, поэтому -u сравнивает ключи по номерам 1..len(Keys);
+	// -r уже применен при сравнении
+	keyOpts := opts
+	keyOpts.Reverse = false
+	keyOpts.Keys = make([]domain.SortKey, len(opts.Keys))
+	for k := range keyOpts.Keys {
+		keyOpts.Keys[k].Field = k + 1
+	}
+	return joinRecords(records, func(s string) string { return s }, keyOpts)
+}
+
+// compareJSONValues сравнивает значения ключа; typed означает явный тип ключа.
+// С reverse значения сравниваются в обратном порядке (флаг -r), а null и отсутствующие
+// значения остаются на месте, заданном nullsFirst.
+func compareJSONValues(i, j jsonValue, typed bool, less func(string, string) bool, nullsFirst, reverse bool) int {
+	if reverse && i.kind != jsonMissing && j.kind != jsonMissing {
+		i, j = j, i
+	}
+	switch {
+	case i.kind == jsonMissing && j.kind == jsonMissing:
+		return 0
+	case i.kind == jsonMissing || j.kind == jsonMissing:
+		if (i.kind == jsonMissing) == nullsFirst {
+			return -1
+		}
+		return 1
+	case !typed && i.kind != j.kind:
+		return i.kind - j.kind
+	case !typed && i.kind == jsonNumber && i.num != j.num:
+		if i.num < j.num {
+			return -1
+		}
+		return 1
+	case !typed && i.kind == jsonNumber:
+		return 0
+	case less(i.text, j.text):
+		return -1
+	case less(j.text, i.text):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// decodeJSONLine разбирает строку как один документ JSON, сохраняя числа в исходной записи.
+func decodeJSONLine(line string) (any, bool) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, false
+	}
+	if decoder.More() {
+		return nil, false
+	}
+	return doc, true
+}
+
+// lookupJSON извлекает значение по пути; отсутствующее поле дает jsonMissing.
+func lookupJSON(doc any, path []string) jsonValue {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return jsonValue{}
+			}
+			doc = value
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return jsonValue{}
+			}
+			doc = node[i]
+		default:
+			return jsonValue{}
+		}
+	}
+	return newJSONValue(doc)
+}
+
+// newJSONValue определяет вид значения JSON и его текстовую запись.
+func newJSONValue(v any) jsonValue {
+	switch v := v.(type) {
+	case nil:
+		return jsonValue{}
+	case json.Number:
+		num, _ := v.Float64()
+		return jsonValue{kind: jsonNumber, num: num, text: v.String()}
+	case string:
+		return jsonValue{kind: jsonString, text: v}
+	case bool:
+		return jsonValue{kind: jsonBool, text: strconv.FormatBool(v)}
+	default:
+		var b bytes.Buffer
+		encoder := json.NewEncoder(&b)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(v)
+		return jsonValue{kind: jsonComposite, text: strings.TrimSuffix(b.String(), "\n")}
+	}
+}
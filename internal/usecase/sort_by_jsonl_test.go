@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{name: "json pointer", path: "/request/duration_ms", expected: []string{"request", "duration_ms"}},
+		{name: "pointer escapes", path: "/a~1b/c~0d", expected: []string{"a/b", "c~d"}},
+		{name: "dot path", path: ".request.duration_ms", expected: []string{"request", "duration_ms"}},
+		{name: "dot path with index", path: ".items[0].name", expected: []string{"items", "0", "name"}},
+		{name: "whole document", path: ".", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tokens, err := parseJSONPath(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tokens)
+		})
+	}
+
+	for _, path := range []string{"ts", ".a..b", ".items[x]", ".items[0"} {
+		t.Run("invalid "+path, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseJSONPath(path)
+			require.ErrorIs(t, err, domain.ErrInvalidJSONPath)
+		})
+	}
+}
+
+func TestSortByJSONL(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "numbers numerically by pointer",
+			input:    `{"ms":120}` + "\n" + `{"ms":15}` + "\n" + `{"ms":1e1}`,
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Path: "/ms"}}},
+			expected: `{"ms":1e1}` + "\n" + `{"ms":15}` + "\n" + `{"ms":120}`,
+		},
+		{
+			name:  "typed key and second key",
+			input: `{"r":{"d":"15"},"ts":"b"}` + "\n" + `{"r":{"d":"120"},"ts":"a"}` + "\n" + `{"r":{"d":"15"},"ts":"a"}`,
+			opts: domain.SortOptions{Keys: []domain.SortKey{
+				{Path: ".r.d", Type: "n"},
+				{Path: ".ts"},
+			}},
+			expected: `{"r":{"d":"15"},"ts":"a"}` + "\n" + `{"r":{"d":"15"},"ts":"b"}` + "\n" + `{"r":{"d":"120"},"ts":"a"}`,
+		},
+		{
+			name:     "null, missing and invalid lines last",
+			input:    `{"v":null}` + "\n" + "oops" + "\n" + `{"v":"x"}` + "\n" + `{}`,
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Path: "/v"}}},
+			expected: `{"v":"x"}` + "\n" + `{"v":null}` + "\n" + "oops" + "\n" + `{}`,
+		},
+		{
+			name:     "null first",
+			input:    `{"v":1}` + "\n" + `{"v":null}`,
+			opts:     domain.SortOptions{JSONNull: domain.PlaceFirst, Keys: []domain.SortKey{{Path: "/v"}}},
+			expected: `{"v":null}` + "\n" + `{"v":1}`,
+		},
+		{
+			name:     "reverse keeps null last",
+			input:    `{"v":null}` + "\n" + `{"v":1}` + "\n" + `{"v":2}`,
+			opts:     domain.SortOptions{Reverse: true, Keys: []domain.SortKey{{Path: "/v"}}},
+			expected: `{"v":2}` + "\n" + `{"v":1}` + "\n" + `{"v":null}`,
+		},
+		{
+			name:     "mixed types by kind",
+			input:    `{"v":true}` + "\n" + `{"v":"a"}` + "\n" + `{"v":[1]}` + "\n" + `{"v":2}`,
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Path: "/v"}}},
+			expected: `{"v":2}` + "\n" + `{"v":"a"}` + "\n" + `{"v":true}` + "\n" + `{"v":[1]}`,
+		},
+		{
+			name:     "array index",
+			input:    `{"a":[3,"z"]}` + "\n" + `{"a":[1,"y"]}`,
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Path: ".a[1]"}}},
+			expected: `{"a":[1,"y"]}` + "\n" + `{"a":[3,"z"]}`,
+		},
+		{
+			name:     "unique by key",
+			input:    `{"id":1,"n":"a"}` + "\n" + `{"id":1,"n":"b"}` + "\n" + `{"id":2}`,
+			opts:     domain.SortOptions{Unique: true, Keys: []domain.SortKey{{Path: "/id"}}},
+			expected: `{"id":1,"n":"a"}` + "\n" + `{"id":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByJSONL(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
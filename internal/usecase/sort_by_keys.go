@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// keyTypes сопоставляет типам ключей (суффикс ":type" в --order-by) флаги сортировки.
+var keyTypes = map[string]func(*domain.SortOptions){
+	"text":     func(*domain.SortOptions) {},
+	"n":        func(o *domain.SortOptions) { o.Numeric = true },
+	"numeric":  func(o *domain.SortOptions) { o.Numeric = true },
+	"h":        func(o *domain.SortOptions) { o.HumanNumeric = true },
+	"human":    func(o *domain.SortOptions) { o.HumanNumeric = true },
+	"M":        func(o *domain.SortOptions) { o.Month = true },
+	"month":    func(o *domain.SortOptions) { o.Month = true },
+	"weekday":  func(o *domain.SortOptions) { o.Weekday = true },
+	"date":     func(o *domain.SortOptions) { o.Date = true },
+	"ip":       func(o *domain.SortOptions) { o.IP = true },
+	"domain":   func(o *domain.SortOptions) { o.Domain = true },
+	"email":    func(o *domain.SortOptions) { o.Email = true },
+	"url":      func(o *domain.SortOptions) { o.URL = true },
+	"path":     func(o *domain.SortOptions) { o.Path = true },
+	"duration": func(o *domain.SortOptions) { o.Duration = true },
+}
+
+// splitKeyType отделяет от ключа суффикс типа ":type", если тип известен.
+func splitKeyType(spec string) (path, typ string) {
+	if i := strings.LastIndex(spec, ":"); i >= 0 {
+		if _, ok := keyTypes[spec[i+1:]]; ok {
+			return spec[:i], spec[i+1:]
+		}
+	}
+	return spec, ""
+}
+
+// keyTypeOptions возвращает опции сравнения ключа типа typ: тип ключа заменяет
+// тип сортировки из флагов (-n, -M, ...). Пустой тип оставляет флаги без изменений.
+func keyTypeOptions(opts domain.SortOptions, typ string) domain.SortOptions {
+	set, ok := keyTypes[typ]
+	if !ok {
+		return opts
+	}
+
+	opts.Numeric, opts.HumanNumeric, opts.Month, opts.Weekday, opts.Date = false, false, false, false, false
+	opts.IP, opts.Domain, opts.Email, opts.URL, opts.Path = false, false, false, false, false
+	opts.Duration = false
+	opts.Metric, opts.Order = "", nil
+	set(&opts)
+	return opts
+}
+
+// parseOrderBy разбирает список ключей в стиле SQL ORDER BY:
+// "latency:n DESC NULLS LAST, host". Колонка задается именем из заголовка names
+// (сначала точное совпадение, затем без учета регистра) или номером поля.
+//
+// Примеры:
+//
+//	"host" → [{Field: 1}] для заголовка "host latency"
+//	"latency:n DESC, host" → [{Field: 2, Type: "n", Desc: true}, {Field: 1}]
+func parseOrderBy(spec string, names []string) ([]domain.SortKey, error) {
+	var keys []domain.SortKey
+	for _, item := range strings.Split(spec, ",") {
+		words := strings.Fields(item)
+		if len(words) == 0 {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidOrderBy, spec)
+		}
+
+		name, typ := words[0], ""
+		if i := strings.LastIndex(name, ":"); i >= 0 && columnIndex(name, names) < 0 {
+			name, typ = name[:i], name[i+1:]
+			if _, ok := keyTypes[typ]; !ok {
+				return nil, fmt.Errorf("%w: %q", domain.ErrInvalidKeyType, typ)
+			}
+		}
+		key := domain.SortKey{Field: columnIndex(name, names) + 1, Type: typ}
+		if key.Field == 0 {
+			return nil, fmt.Errorf("%w: %q", domain.ErrUnknownColumn, name)
+		}
+
+		modifiers := strings.ToUpper(strings.Join(words[1:], " "))
+		switch {
+		case strings.HasPrefix(modifiers, "ASC"):
+			modifiers = strings.TrimSpace(modifiers[len("ASC"):])
+		case strings.HasPrefix(modifiers, "DESC"):
+			key.Desc = true
+			modifiers = strings.TrimSpace(modifiers[len("DESC"):])
+		}
+		switch modifiers {
+		case "":
+		case "NULLS FIRST":
+			key.Nulls = domain.PlaceFirst
+		case "NULLS LAST":
+			key.Nulls = domain.PlaceLast
+		default:
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidOrderBy, item)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// parseKeySpecs разбирает значения флагов -k в ключи сортировки. Единственный -k N[:type]
+// сохраняет прежний смысл (opts.Key и opts.Field), а тип ключа заменяет тип сортировки
+// из флагов, поэтому -k 2:n и -k 2 -n сортируют одинаково. Иначе каждый -k задает
+// очередной ключ "N[:type]" (записи сравниваются как с --order-by: отсутствующие поля
+// идут последними), с --jsonl — путь "/pointer[:type]" или ".path[:type]",
+// с --logfmt — имя ключа "name[:type]"; с --jsonl без -k ключом служит документ целиком (".").
+//
+// Примеры:
+//
+//	["2"] → Key: true, Field: 2
+//	["2:n"] → Key: true, Field: 2, Numeric: true
+//	["3:n", "1"] → Keys: [{Field: 3, Type: "n"}, {Field: 1}]
+//	["/request/duration_ms:n", "/ts"] с --jsonl → Keys с путями
+//	["dur:duration", "user"] с --logfmt → Keys с именами
+func parseKeySpecs(opts *domain.SortOptions) error {
+	defer func() { opts.KeySpecs = nil }() // повторный вызов Sort (с --header) не дублирует ключи
+	if len(opts.KeySpecs) == 0 && len(opts.Keys) == 0 && opts.JSONL {
+		// Без -k строки JSON Lines сравниваются по документу целиком; ключи, разобранные
+		// до повторного вызова Sort (секции --sections), не дополняются
+		opts.KeySpecs = []string{"."}
+	}
+	if len(opts.KeySpecs) == 0 {
+		return nil
+	}
+	if len(opts.KeySpecs) == 1 && !opts.JSONL && !opts.Logfmt {
+		spec, typ := splitKeyType(opts.KeySpecs[0])
+		if n, err := strconv.Atoi(spec); err == nil {
+			*opts = keyTypeOptions(*opts, typ)
+			opts.Key, opts.Field = true, n
+			return nil
+		}
+	}
+
+	for _, spec := range opts.KeySpecs {
+		var key domain.SortKey
+		spec, key.Type = splitKeyType(spec)
+
+		switch {
+		case opts.JSONL:
+			if _, err := parseJSONPath(spec); err != nil {
+				return err
+			}
+			key.Path = spec
+		case opts.Logfmt:
+			if spec == "" || strings.ContainsAny(spec, "= \t\"") {
+				return fmt.Errorf("%w: %q", domain.ErrInvalidLogfmtKey, spec)
+			}
+			key.Path = spec
+		default:
+			n, err := strconv.Atoi(spec)
+			if i := strings.LastIndex(spec, ":"); err != nil && i >= 0 {
+				// Суффикс типа не распознан splitKeyType: "2:bogus"
+				return fmt.Errorf("%w: %q", domain.ErrInvalidKeyType, spec[i+1:])
+			}
+			if err != nil || n < 1 {
+				return domain.ErrInvalideField
+			}
+			key.Field = n
+		}
+		opts.Keys = append(opts.Keys, key)
+	}
+	return nil
+}
+
+// columnIndex возвращает индекс колонки name в заголовке names или -1.
+// Число вне заголовка считается номером поля.
+func columnIndex(name string, names []string) int {
+	for i, column := range names {
+		if column == name {
+			return i
+		}
+	}
+	for i, column := range names {
+		if strings.EqualFold(column, name) {
+			return i
+		}
+	}
+	if n, err := strconv.Atoi(name); err == nil && n >= 1 {
+		return n - 1
+	}
+	return -1
+}
+
+// SortByKeys выполняет многоключевую сортировку (флаг --order-by).
+// Записи сравниваются по ключам opts.Keys слева направо: каждый ключ со своим типом
+// и направлением. Пустые и отсутствующие значения (NULL) по умолчанию идут последними
+// при ASC и первыми при DESC, как в PostgreSQL; NULLS FIRST и NULLS LAST меняют это.
+// С --csv поля — ячейки CSV, иначе поля разделяются как для -k.
+//
+// Примеры (колонки "host latency"):
+//
+//	"a 30\nb 5\nc" с "latency:n DESC" → "c\na 30\nb 5"
+//	"a 30\nb 5\nc" с "latency:n DESC NULLS LAST" → "a 30\nb 5\nc"
+func SortByKeys(s string, modify func(string) string, opts domain.SortOptions) (string, error) {
+	records, err := splitRecords(s, opts)
+	if err != nil {
+		return "", err
+	}
+
+	sortRecords(records, modify, opts)
+	return joinRecords(records, modify, opts), nil
+}
+
+// sortRecords сортирует записи по ключам opts.Keys по правилам SortByKeys.
+func sortRecords(records []record, modify func(string) string, opts domain.SortOptions) {
+	comparators := make([]func(iKey, jKey string) bool, len(opts.Keys))
+	for k, key := range opts.Keys {
+		comparators[k] = keyComparator(keyTypeOptions(opts, key.Type))
+	}
+
+	compare := func(i, j record) int {
+		for k, key := range opts.Keys {
+			iKey, iNull := recordKey(i, key.Field, modify)
+			jKey, jNull := recordKey(j, key.Field, modify)
+
+			nullsFirst := key.Nulls == domain.PlaceFirst || (key.Nulls == "" && key.Desc)
+			switch {
+			case iNull && jNull:
+				continue
+			case iNull != jNull:
+				if iNull == nullsFirst {
+					return -1
+				}
+				return 1
+			}
+
+			less := comparators[k]
+			if key.Desc {
+				iKey, jKey = jKey, iKey
+			}
+			if less(iKey, jKey) {
+				return -1
+			}
+			if less(jKey, iKey) {
+				return 1
+			}
+		}
+		return 0
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if cmp := compare(records[i], records[j]); cmp != 0 {
+			return cmp < 0
+		}
+		if opts.Tiebreak == "" {
+			return false
+		}
+		// Ключи равны: сравнение записей целиком по метрике (флаг --tiebreak-metric)
+		return lineMetric(modify(records[i].raw), opts.Tiebreak) < lineMetric(modify(records[j].raw), opts.Tiebreak)
+	})
+}
+
+// recordKey возвращает значение поля field записи и признак NULL:
+// поле отсутствует или пусто после преобразования ключа.
+func recordKey(r record, field int, modify func(string) string) (string, bool) {
+	if field > len(r.fields) {
+		return "", true
+	}
+	key := modify(r.fields[field-1])
+	return key, strings.TrimSpace(key) == ""
+}
+
+// splitRecords разбивает ввод на записи: с --csv — записи CSV, иначе строки с полями -t.
+func splitRecords(s string, opts domain.SortOptions) ([]record, error) {
+	if opts.CSV {
+		dialect, _ := newCSVDialect(opts) // формат проверяется в Sort
+		return parseCSV(s, dialect)
+	}
+
+	lines := strings.Split(s, "\n")
+	records := make([]record, len(lines))
+	for i, line := range lines {
+		records[i] = record{raw: line, fields: lineFields(line, opts)}
+	}
+	return records, nil
+}
+
+// joinRecords собирает отсортированные записи в текст, применяя -r и -u к записям целиком.
+func joinRecords(records []record, modify func(string) string, opts domain.SortOptions) string {
+	records = orderRecords(records, modify, opts)
+	result := make([]string, len(records))
+	for i, rec := range records {
+		result[i] = rec.raw
+	}
+	return strings.Join(result, "\n")
+}
+
+// orderRecords применяет к отсортированным записям -r и -u. Уникальность определяется
+// по ключам --order-by, полю -k или всем полям записи.
+func orderRecords(records []record, modify func(string) string, opts domain.SortOptions) []record {
+	if opts.Reverse {
+		for l, r := 0, len(records)-1; l < r; l, r = l+1, r-1 {
+			records[l], records[r] = records[r], records[l]
+		}
+	}
+	if !opts.Unique {
+		return records
+	}
+
+	result := make([]record, 0, len(records))
+	seen := make(map[string]bool)
+	for _, rec := range records {
+		if key := uniqueKey(rec, modify, opts); !seen[key] {
+			seen[key] = true
+			result = append(result, rec)
+		}
+	}
+	return result
+}
+
+// uniqueKey строит ключ уникальности записи для joinRecords.
+func uniqueKey(rec record, modify func(string) string, opts domain.SortOptions) string {
+	var fields []int
+	switch {
+	case len(opts.Keys) > 0:
+		for _, key := range opts.Keys {
+			fields = append(fields, key.Field)
+		}
+	case opts.Field >= 1:
+		fields = []int{opts.Field}
+	default:
+		return modify(strings.Join(rec.fields, "\x00"))
+	}
+
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i], _ = recordKey(rec, field, modify)
+	}
+	return strings.Join(parts, "\x00")
+}
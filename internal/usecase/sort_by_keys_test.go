@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	names := []string{"host", "Latency", "date"}
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected []domain.SortKey
+	}{
+		{
+			name:     "single column",
+			spec:     "host",
+			expected: []domain.SortKey{{Field: 1}},
+		},
+		{
+			name: "types, directions and nulls",
+			spec: "latency:n DESC NULLS LAST, date:date asc, host",
+			expected: []domain.SortKey{
+				{Field: 2, Type: "n", Desc: true, Nulls: domain.PlaceLast},
+				{Field: 3, Type: "date"},
+				{Field: 1},
+			},
+		},
+		{
+			name:     "nulls without direction",
+			spec:     "host nulls first",
+			expected: []domain.SortKey{{Field: 1, Nulls: domain.PlaceFirst}},
+		},
+		{
+			name:     "field number",
+			spec:     "4:h desc",
+			expected: []domain.SortKey{{Field: 4, Type: "h", Desc: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			keys, err := parseOrderBy(tt.spec, names)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, keys)
+		})
+	}
+}
+
+func TestParseOrderByErrors(t *testing.T) {
+	names := []string{"host", "latency"}
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr error
+	}{
+		{name: "unknown column", spec: "region", wantErr: domain.ErrUnknownColumn},
+		{name: "unknown type", spec: "latency:float", wantErr: domain.ErrInvalidKeyType},
+		{name: "bad direction", spec: "host DOWN", wantErr: domain.ErrInvalidOrderBy},
+		{name: "empty item", spec: "host,,latency", wantErr: domain.ErrInvalidOrderBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseOrderBy(tt.spec, names)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseKeySpecs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     domain.SortOptions
+		expected domain.SortOptions
+		wantErr  error
+	}{
+		{
+			name:     "single field keeps -k N",
+			opts:     domain.SortOptions{KeySpecs: []string{"2"}},
+			expected: domain.SortOptions{Key: true, Field: 2},
+		},
+		{
+			name:     "single typed field is -k N with type",
+			opts:     domain.SortOptions{KeySpecs: []string{"2:n"}, Month: true},
+			expected: domain.SortOptions{Key: true, Field: 2, Numeric: true},
+		},
+		{
+			name: "typed and repeated keys",
+			opts: domain.SortOptions{KeySpecs: []string{"3:n", "1"}},
+			expected: domain.SortOptions{Keys: []domain.SortKey{
+				{Field: 3, Type: "n"},
+				{Field: 1},
+			}},
+		},
+		{
+			name: "json paths",
+			opts: domain.SortOptions{JSONL: true, KeySpecs: []string{"/request/duration_ms:n", ".ts"}},
+			expected: domain.SortOptions{JSONL: true, Keys: []domain.SortKey{
+				{Path: "/request/duration_ms", Type: "n"},
+				{Path: ".ts"},
+			}},
+		},
+		{
+			name:     "jsonl keys parsed before are kept",
+			opts:     domain.SortOptions{JSONL: true, Keys: []domain.SortKey{{Path: ".v"}}},
+			expected: domain.SortOptions{JSONL: true, Keys: []domain.SortKey{{Path: ".v"}}},
+		},
+		{
+			name:     "jsonl without keys",
+			opts:     domain.SortOptions{JSONL: true},
+			expected: domain.SortOptions{JSONL: true, Keys: []domain.SortKey{{Path: "."}}},
+		},
+		{
+			name: "logfmt key names",
+			opts: domain.SortOptions{Logfmt: true, KeySpecs: []string{"dur:duration", "2"}},
+			expected: domain.SortOptions{Logfmt: true, Keys: []domain.SortKey{
+				{Path: "dur", Type: "duration"},
+				{Path: "2"},
+			}},
+		},
+		{
+			name:    "logfmt key with quote",
+			opts:    domain.SortOptions{Logfmt: true, KeySpecs: []string{`us"er`}},
+			wantErr: domain.ErrInvalidLogfmtKey,
+		},
+		{
+			name:    "unknown key type",
+			opts:    domain.SortOptions{KeySpecs: []string{"2:bogus"}},
+			wantErr: domain.ErrInvalidKeyType,
+		},
+		{
+			name:    "unknown type of second key",
+			opts:    domain.SortOptions{KeySpecs: []string{"1", "2:bogus"}},
+			wantErr: domain.ErrInvalidKeyType,
+		},
+		{
+			name:    "field is not a number",
+			opts:    domain.SortOptions{KeySpecs: []string{"2", "host"}},
+			wantErr: domain.ErrInvalideField,
+		},
+		{
+			name:    "jsonl key is not a path",
+			opts:    domain.SortOptions{JSONL: true, KeySpecs: []string{"2"}},
+			wantErr: domain.ErrInvalidJSONPath,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := tt.opts
+			err := parseKeySpecs(&opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, opts)
+		})
+	}
+
+	// Тип ключа и флаг типа упорядочивают строки без поля одинаково
+	input := "b 10\na\nc 9"
+	typed, err := Sort(input, domain.SortOptions{KeySpecs: []string{"2:n"}})
+	require.NoError(t, err)
+	flagged, err := Sort(input, domain.SortOptions{KeySpecs: []string{"2"}, Numeric: true})
+	require.NoError(t, err)
+	require.Equal(t, "a\nc 9\nb 10", typed)
+	require.Equal(t, typed, flagged)
+}
+
+func TestSortByKeys(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "numeric descending, nulls first by default",
+			input:    "a 30\nb 5\nc\nd 100",
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Field: 2, Type: "n", Desc: true}}},
+			expected: "c\nd 100\na 30\nb 5",
+		},
+		{
+			name:     "nulls last",
+			input:    "a 30\nc\nb 5",
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Field: 2, Type: "n", Desc: true, Nulls: domain.PlaceLast}}},
+			expected: "a 30\nb 5\nc",
+		},
+		{
+			name:  "second key breaks ties",
+			input: "web 5\ndb 5\napi 1",
+			opts: domain.SortOptions{Keys: []domain.SortKey{
+				{Field: 2, Type: "n"},
+				{Field: 1},
+			}},
+			expected: "api 1\ndb 5\nweb 5",
+		},
+		{
+			name:  "per-column types",
+			input: "x 2026-03-01\ny 2026-01-15",
+			opts: domain.SortOptions{Keys: []domain.SortKey{
+				{Field: 2, Type: "date"},
+			}},
+			expected: "y 2026-01-15\nx 2026-03-01",
+		},
+		{
+			name:     "csv cells",
+			input:    "\"b, x\",2\n\"a, y\",1",
+			opts:     domain.SortOptions{CSV: true, Keys: []domain.SortKey{{Field: 2, Type: "n"}}},
+			expected: "\"a, y\",1\n\"b, x\",2",
+		},
+		{
+			name:     "unique by key columns",
+			input:    "a 1\nb 1\nc 2",
+			opts:     domain.SortOptions{Unique: true, Keys: []domain.SortKey{{Field: 2, Type: "n"}}},
+			expected: "a 1\nc 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := SortByKeys(tt.input, identity, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unix_sort_lite/internal/domain"
+)
+
+// logTimeWindow ограничивает позицию, с которой может начинаться метка времени.
+// Метки, найденные дальше от начала строки, считаются частью сообщения.
+const logTimeWindow = 64
+
+// logTimeParser разбирает метки времени логов: loc — зона меток без зоны (--timezone),
+// now — момент, относительно которого выводится год меток syslog.
+type logTimeParser struct {
+	loc *time.Location
+	now time.Time
+}
+
+// logTimeFormat описывает одну распознаваемую форму метки времени в логах.
+type logTimeFormat struct {
+	regex *regexp.Regexp
+	parse func(match []string, p logTimeParser) (time.Time, bool)
+}
+
+// logTimeFormats перечисляет формы меток в порядке приоритета при совпадении позиций:
+// ISO 8601, Apache/nginx access log, syslog (RFC 3164) и Unix epoch в секундах/миллисекундах.
+var logTimeFormats = []logTimeFormat{
+	{
+		regex: regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?`),
+		parse: parseISOLogTime,
+	},
+	{
+		regex: regexp.MustCompile(`\[(\d{2}/[A-Za-z]{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4})\]`),
+		parse: parseApacheLogTime,
+	},
+	{
+		regex: regexp.MustCompile(`\b([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2})\b`),
+		parse: parseSyslogTime,
+	},
+	{
+		regex: regexp.MustCompile(`\b(\d{13}|\d{10}(?:\.\d+)?)\b`),
+		parse: parseEpochLogTime,
+	},
+}
+
+// logRecord объединяет строку с меткой времени и следующие за ней строки без метки
+// (продолжения stack trace, многострочные сообщения).
+type logRecord struct {
+	lines   []string
+	time    time.Time
+	stamped bool
+}
+
+// SortByLogTime выполняет хронологическую сортировку логов разных форматов (флаг --log-time).
+// Метка времени ищется в начале каждой строки (не дальше logTimeWindow байт) и может быть
+// в формате ISO 8601, Apache access log, syslog или Unix epoch. Для syslog год не указан
+// и выводится из текущей даты: метка из будущего относится к прошлому году. Метки без зоны
+// интерпретируются в зоне --timezone (по умолчанию UTC).
+//
+// Строки без метки присоединяются к предыдущей строке с меткой и перемещаются вместе с ней.
+// Строки до первой метки остаются в начале. Флаги -r и -u применяются к записям, а не
+// к строкам: с -u повторяющаяся запись (совпадают все ее строки) выводится один раз.
+//
+// Примеры:
+//
+//	"2026-10-18T12:00:02Z b\n[18/Oct/2026:12:00:01 +0000] a" → "[18/Oct/2026:12:00:01 +0000] a\n2026-10-18T12:00:02Z b"
+//	"1760788802 b\n1760788801 a\n  at main()" → "1760788801 a\n  at main()\n1760788802 b"
+func SortByLogTime(s string, modify func(string) string, opts domain.SortOptions) string {
+	dates, _ := newDateParser(opts) // зона проверяется в Sort
+	return logTimeParser{loc: dates.loc, now: time.Now()}.sort(s, modify, opts)
+}
+
+// sort сортирует записи лога по правилам SortByLogTime.
+func (p logTimeParser) sort(s string, modify func(string) string, opts domain.SortOptions) string {
+	var records []logRecord
+	for _, line := range strings.Split(s, "\n") {
+		t, ok := p.find(modify(line))
+		if ok || len(records) == 0 {
+			records = append(records, logRecord{time: t, stamped: ok})
+		}
+		last := &records[len(records)-1]
+		last.lines = append(last.lines, line)
+	}
+
+	// Строки до первой метки остаются в начале и с -r: сортируются только записи с меткой
+	stamped := records
+	if len(stamped) > 0 && !stamped[0].stamped {
+		stamped = stamped[1:]
+	}
+	sort.SliceStable(stamped, func(i, j int) bool {
+		if opts.Reverse {
+			i, j = j, i
+		}
+		return stamped[i].time.Before(stamped[j].time)
+	})
+
+	rows := make([]string, 0, len(records))
+	seen := make(map[string]bool)
+	for _, record := range records {
+		if opts.Unique {
+			lines := make([]string, len(record.lines))
+			for i, line := range record.lines {
+				lines[i] = modify(line)
+			}
+			key := strings.Join(lines, "\n")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		rows = append(rows, record.lines...)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// find ищет самую раннюю метку времени в начале строки.
+// При совпадении позиций побеждает формат, стоящий раньше в logTimeFormats.
+func (p logTimeParser) find(line string) (time.Time, bool) {
+	head := line
+	if len(head) > logTimeWindow+32 {
+		head = head[:logTimeWindow+32]
+	}
+
+	var (
+		best    time.Time
+		bestPos = -1
+	)
+	for _, format := range logTimeFormats {
+		idx := format.regex.FindStringSubmatchIndex(head)
+		if idx == nil || idx[0] > logTimeWindow || (bestPos >= 0 && idx[0] >= bestPos) {
+			continue
+		}
+		match := make([]string, len(idx)/2)
+		for k := range match {
+			if idx[2*k] >= 0 {
+				match[k] = head[idx[2*k]:idx[2*k+1]]
+			}
+		}
+		if t, ok := format.parse(match, p); ok {
+			best, bestPos = t, idx[0]
+		}
+	}
+	return best, bestPos >= 0
+}
+
+// parseISOLogTime разбирает метки ISO 8601: "2026-10-18T12:00:01.123+03:00",
+// "2026-10-18 12:00:01,123" (запятая в долях секунды, как в log4j/Python logging).
+func parseISOLogTime(match []string, p logTimeParser) (time.Time, bool) {
+	s := strings.Replace(match[0], " ", "T", 1)
+	s = strings.Replace(s, ",", ".", 1)
+	for _, layout := range []string{"2006-01-02T15:04:05Z07:00", "2006-01-02T15:04:05Z0700", "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, s, p.loc); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseApacheLogTime разбирает метки access log Apache/nginx: "18/Oct/2026:12:00:01 +0000".
+func parseApacheLogTime(match []string, _ logTimeParser) (time.Time, bool) {
+	t, err := time.Parse("02/Jan/2006:15:04:05 -0700", match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+// parseSyslogTime разбирает метки syslog без года: "Oct 18 12:00:01", "Oct  8 12:00:01".
+// Год берется из p.now; если метка оказывается в будущем больше чем на сутки,
+// она относится к прошлому году (логи за декабрь, прочитанные в январе).
+func parseSyslogTime(match []string, p logTimeParser) (time.Time, bool) {
+	t, err := time.ParseInLocation("Jan _2 15:04:05", match[1], p.loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	current := p.now.In(p.loc)
+	stamp := time.Date(current.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, p.loc)
+	if stamp.After(current.Add(24 * time.Hour)) {
+		stamp = time.Date(current.Year()-1, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, p.loc)
+	}
+	return stamp.UTC(), true
+}
+
+// parseEpochLogTime разбирает Unix epoch: 10 цифр (секунды, возможно с долями)
+// или 13 цифр (миллисекунды).
+func parseEpochLogTime(match []string, _ logTimeParser) (time.Time, bool) {
+	if len(match[1]) == 13 {
+		ms, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.UnixMilli(ms).UTC(), true
+	}
+	sec, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(sec*float64(time.Second))).UTC(), true
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByLogTime(t *testing.T) {
+	identity := func(s string) string { return s }
+	now := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "iso timestamps",
+			input:    "2026-10-18T12:00:02Z b\n2026-10-18T12:00:01Z a",
+			expected: "2026-10-18T12:00:01Z a\n2026-10-18T12:00:02Z b",
+		},
+		{
+			name: "heterogeneous formats",
+			input: "127.0.0.1 - - [18/Oct/2026:12:00:03 +0000] \"GET / HTTP/1.1\" 200\n" +
+				"Oct 18 12:00:01 host sshd[42]: accepted\n" +
+				"{\"level\":\"info\",\"ts\":1792324804,\"msg\":\"json\"}\n" +
+				"2026-10-18 12:00:02,500 INFO app started",
+			expected: "Oct 18 12:00:01 host sshd[42]: accepted\n" +
+				"2026-10-18 12:00:02,500 INFO app started\n" +
+				"127.0.0.1 - - [18/Oct/2026:12:00:03 +0000] \"GET / HTTP/1.1\" 200\n" +
+				"{\"level\":\"info\",\"ts\":1792324804,\"msg\":\"json\"}",
+		},
+		{
+			name:     "epoch millis",
+			input:    "1760788801500 b\n1760788801250 a",
+			expected: "1760788801250 a\n1760788801500 b",
+		},
+		{
+			name:     "continuation lines follow stamped line",
+			input:    "2026-10-18T12:00:02Z error\n  at main.go:10\n  at main.go:20\n2026-10-18T12:00:01Z start",
+			expected: "2026-10-18T12:00:01Z start\n2026-10-18T12:00:02Z error\n  at main.go:10\n  at main.go:20",
+		},
+		{
+			name:     "lines before first timestamp stay first",
+			input:    "header\n2026-10-18T12:00:02Z b\n2026-10-18T12:00:01Z a",
+			expected: "header\n2026-10-18T12:00:01Z a\n2026-10-18T12:00:02Z b",
+		},
+		{
+			name:     "timestamp far from line start is ignored",
+			input:    "2026-10-18T12:00:02Z b\n" + strings.Repeat("x", 70) + " 2026-10-18T12:00:01Z late",
+			expected: "2026-10-18T12:00:02Z b\n" + strings.Repeat("x", 70) + " 2026-10-18T12:00:01Z late",
+		},
+		{
+			name:     "reverse keeps records intact",
+			input:    "2026-10-18T12:00:01Z a\n  detail\n2026-10-18T12:00:02Z b",
+			opts:     domain.SortOptions{Reverse: true},
+			expected: "2026-10-18T12:00:02Z b\n2026-10-18T12:00:01Z a\n  detail",
+		},
+		{
+			name:     "reverse keeps lines before first timestamp first",
+			input:    "preamble\n2026-10-18T12:00:01Z a\n2026-10-18T12:00:02Z b",
+			opts:     domain.SortOptions{Reverse: true},
+			expected: "preamble\n2026-10-18T12:00:02Z b\n2026-10-18T12:00:01Z a",
+		},
+		{
+			name: "unique removes whole duplicate records",
+			input: "2026-10-18T12:00:02Z b\n  at main()\n2026-10-18T12:00:01Z a\n  at main()\n" +
+				"2026-10-18T12:00:01Z a\n  at main()",
+			opts:     domain.SortOptions{Unique: true},
+			expected: "2026-10-18T12:00:01Z a\n  at main()\n2026-10-18T12:00:02Z b\n  at main()",
+		},
+		{
+			name:     "timezone for zone-less timestamps",
+			input:    "2026-10-18 12:30:00 local\n2026-10-18T10:00:00Z utc",
+			opts:     domain.SortOptions{Timezone: "Europe/Moscow"},
+			expected: "2026-10-18 12:30:00 local\n2026-10-18T10:00:00Z utc",
+		},
+		{
+			name:     "equal timestamps keep input order",
+			input:    "2026-10-18T12:00:00Z b\n2026-10-18T12:00:00Z a",
+			expected: "2026-10-18T12:00:00Z b\n2026-10-18T12:00:00Z a",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dates, err := newDateParser(tt.opts)
+			require.NoError(t, err)
+			result := logTimeParser{loc: dates.loc, now: now}.sort(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParseSyslogTimeYearInference(t *testing.T) {
+	parser := logTimeParser{loc: time.UTC, now: time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name     string
+		stamp    string
+		expected time.Time
+	}{
+		{
+			name:     "current year",
+			stamp:    "Jan  2 09:00:00",
+			expected: time.Date(2026, time.January, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "december belongs to previous year",
+			stamp:    "Dec 31 23:59:59",
+			expected: time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, ok := parseSyslogTime([]string{tt.stamp, tt.stamp}, parser)
+			require.True(t, ok)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// parseLogfmt разбирает строку logfmt на пары ключ-значение. Пары разделяются пробелами,
+// значение в кавычках может содержать пробелы и экранирование в стиле Go ("a \"b\""),
+// ключ без "=" считается присутствующим с пустым значением. При повторе ключа
+// берется последнее значение. Незакрытая кавычка продолжает значение до конца строки.
+//
+// Пример:
+//
+//	`level=info dur=12ms user="a b" debug` →
+//	{"level": "info", "dur": "12ms", "user": "a b", "debug": ""}
+func parseLogfmt(line string) map[string]string {
+	pairs := make(map[string]string)
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		key := line[start:i]
+		if i >= len(line) || line[i] != '=' {
+			pairs[key] = ""
+			continue
+		}
+		i++ // '='
+
+		if i < len(line) && line[i] == '"' {
+			start = i
+			for i++; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' {
+					i++
+				}
+			}
+			i = min(i+1, len(line))
+			value, err := strconv.Unquote(line[start:i])
+			if err != nil {
+				value = strings.Trim(line[start:i], `"`)
+			}
+			pairs[key] = value
+			continue
+		}
+
+		start = i
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		pairs[key] = line[start:i]
+	}
+	return pairs
+}
+
+// SortByLogfmt выполняет сортировку строк logfmt (флаг --logfmt) по ключам -k, заданным
+// именами: "-k dur:duration -k user". Ключ с типом сравнивается как поле -k этого типа,
+// без типа — как текст или по флагам типа (-n, -h, ...). Строки без ключа по умолчанию
+// идут последними, --logfmt-missing=first переносит их в начало, -r на это не влияет;
+// пустое значение (user="") считается присутствующим. Без -k строки сравниваются целиком. Строки выводятся без изменений.
+//
+// Примеры:
+//
+//	"dur=1s\ndur=12ms" с -k dur:duration → "dur=12ms\ndur=1s"
+//	"user=b\nlevel=info\nuser=a" с -k user → "user=a\nuser=b\nlevel=info"
+func SortByLogfmt(s string, modify func(string) string, opts domain.SortOptions) string {
+	missingFirst := opts.LogfmtMissing == domain.PlaceFirst
+	comparators := make([]func(iKey, jKey string) bool, len(opts.Keys))
+	for k, key := range opts.Keys {
+		comparators[k] = keyComparator(keyTypeOptions(opts, key.Type))
+	}
+
+	lines := strings.Split(s, "\n")
+	rows := make([]jsonRow, len(lines))
+	for i, line := range lines {
+		if opts.StripANSI {
+			line = stripANSI(line)
+		}
+		pairs := parseLogfmt(line)
+		row := jsonRow{
+			record: record{raw: lines[i], fields: make([]string, len(opts.Keys))},
+			values: make([]jsonValue, len(opts.Keys)),
+		}
+		for k, key := range opts.Keys {
+			if value, ok := pairs[key.Path]; ok {
+				row.values[k] = jsonValue{kind: jsonString, text: modify(value)}
+			}
+			row.fields[k] = row.values[k].text
+		}
+		rows[i] = row
+	}
+
+	less := keyComparator(opts)
+	sort.SliceStable(rows, func(i, j int) bool {
+		if len(opts.Keys) == 0 {
+			if opts.Reverse {
+				return less(modify(rows[j].raw), modify(rows[i].raw))
+			}
+			return less(modify(rows[i].raw), modify(rows[j].raw))
+		}
+		for k := range opts.Keys {
+			cmp := compareJSONValues(rows[i].values[k], rows[j].values[k], true, comparators[k], missingFirst, opts.Reverse)
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+
+	records := make([]record, len(rows))
+	for i, row := range rows {
+		records[i] = row.record
+		if len(opts.Keys) == 0 {
+			records[i].fields = []string{modify(row.raw)}
+		}
+	}
+	// Поля записей — значения ключей (или строка целиком без -k), поэтому -u сравнивает
+	// ключи по номерам 1..len(Keys); -r уже применен при сравнении
+	keyOpts := opts
+	keyOpts.Reverse = false
+	keyOpts.Keys = make([]domain.SortKey, len(opts.Keys))
+	for k := range keyOpts.Keys {
+		keyOpts.Keys[k].Field = k + 1
+	}
+	return joinRecords(records, func(s string) string { return s }, keyOpts)
+}
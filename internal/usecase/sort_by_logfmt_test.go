@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected map[string]string
+	}{
+		{
+			name:     "plain and quoted values",
+			line:     `level=info dur=12ms user="a b"`,
+			expected: map[string]string{"level": "info", "dur": "12ms", "user": "a b"},
+		},
+		{
+			name:     "escapes in quotes",
+			line:     `msg="say \"hi\"\tnow" path=/a`,
+			expected: map[string]string{"msg": "say \"hi\"\tnow", "path": "/a"},
+		},
+		{
+			name:     "bare key and empty values",
+			line:     `debug user= name=""`,
+			expected: map[string]string{"debug": "", "user": "", "name": ""},
+		},
+		{
+			name:     "repeated key takes last",
+			line:     `a=1  a=2`,
+			expected: map[string]string{"a": "2"},
+		},
+		{
+			name:     "unterminated quote",
+			line:     `a=1 msg="oops here`,
+			expected: map[string]string{"a": "1", "msg": "oops here"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, parseLogfmt(tt.line))
+		})
+	}
+}
+
+func TestSortByLogfmt(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "duration key",
+			input:    "level=info dur=1.5s\nlevel=warn dur=120ms\nlevel=info dur=2m",
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Path: "dur", Type: "duration"}}},
+			expected: "level=warn dur=120ms\nlevel=info dur=1.5s\nlevel=info dur=2m",
+		},
+		{
+			name:  "quoted value and second key",
+			input: `user="b c" n=2` + "\n" + `user="a z" n=3` + "\n" + `user="b c" n=10`,
+			opts: domain.SortOptions{Keys: []domain.SortKey{
+				{Path: "user"},
+				{Path: "n", Type: "n"},
+			}},
+			expected: `user="a z" n=3` + "\n" + `user="b c" n=2` + "\n" + `user="b c" n=10`,
+		},
+		{
+			name:     "missing keys last, empty value present",
+			input:    "level=info\nuser=b\nuser=\nuser=a",
+			opts:     domain.SortOptions{Keys: []domain.SortKey{{Path: "user"}}},
+			expected: "user=\nuser=a\nuser=b\nlevel=info",
+		},
+		{
+			name:     "missing keys first",
+			input:    "user=a\nlevel=info",
+			opts:     domain.SortOptions{LogfmtMissing: domain.PlaceFirst, Keys: []domain.SortKey{{Path: "user"}}},
+			expected: "level=info\nuser=a",
+		},
+		{
+			name:     "reverse keeps missing keys last",
+			input:    "dur=1s\ndur=12ms\nlevel=info",
+			opts:     domain.SortOptions{Reverse: true, Keys: []domain.SortKey{{Path: "dur", Type: "duration"}}},
+			expected: "dur=1s\ndur=12ms\nlevel=info",
+		},
+		{
+			name:     "reverse whole lines without keys",
+			input:    "a=2\nb=1",
+			opts:     domain.SortOptions{Reverse: true},
+			expected: "b=1\na=2",
+		},
+		{
+			name:     "unique by key",
+			input:    "id=1 n=a\nid=2\nid=1 n=b",
+			opts:     domain.SortOptions{Unique: true, Keys: []domain.SortKey{{Path: "id"}}},
+			expected: "id=1 n=a\nid=2",
+		},
+		{
+			name:     "whole lines without keys",
+			input:    "b=1\na=2",
+			opts:     domain.SortOptions{},
+			expected: "a=2\nb=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.opts.Logfmt = true
+			result := SortByLogfmt(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unix_sort_lite/internal/domain"
+
+	"golang.org/x/text/width"
+)
+
+// Метрики строки для флагов --metric и --tiebreak-metric
+const (
+	metricBytes = "bytes" // длина в байтах
+	metricRunes = "runes" // количество символов Unicode
+	metricWidth = "width" // ширина в колонках терминала
+	metricWords = "words" // количество слов (полей, разделенных пробелами)
+)
+
+// validateMetric проверяет имя метрики; пустое имя означает, что метрика не задана.
+func validateMetric(metric string) error {
+	switch metric {
+	case "", metricBytes, metricRunes, metricWidth, metricWords:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", domain.ErrInvalidMetric, metric)
+	}
+}
+
+// SortByMetric выполняет сортировку по метрике строки (флаг --metric).
+// Строки упорядочиваются по длине в байтах, количеству символов, ширине
+// в терминале или количеству слов; строки с равной метрикой сортируются
+// лексикографически, что дает shortlex-порядок для списков слов.
+//
+// Примеры:
+//
+//	"ccc\na\nbb" с --metric=runes → "a\nbb\nccc"
+//	"bb\nab\nc" с --metric=runes → "c\nab\nbb" (shortlex)
+//	"日本\nabc" с --metric=width → "abc\n日本" (ширина 3 < 4)
+func SortByMetric(s string, modify func(string) string, opts domain.SortOptions) string {
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareMetricStrings(modify(rows[i]), modify(rows[j]), opts.Metric)
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareMetricStrings сравнивает две строки по метрике, а при равенстве — лексикографически.
+//
+// Примеры порядка (--metric=runes):
+//
+//	"" < "a" < "b" < "ab" < "ёж" < "abc"
+func compareMetricStrings(iStr, jStr, metric string) bool {
+	iMetric, jMetric := lineMetric(iStr, metric), lineMetric(jStr, metric)
+	if iMetric != jMetric {
+		return iMetric < jMetric
+	}
+	return iStr < jStr
+}
+
+// lineMetric вычисляет метрику строки. Неизвестная метрика считается длиной в байтах.
+func lineMetric(s, metric string) int {
+	switch metric {
+	case metricRunes:
+		return utf8.RuneCountInString(s)
+	case metricWidth:
+		return displayWidth(s)
+	case metricWords:
+		return len(strings.Fields(s))
+	default:
+		return len(s)
+	}
+}
+
+// displayWidth вычисляет ширину строки в колонках терминала: широкие и полноширинные
+// символы East Asian занимают две колонки, комбинируемые знаки, символы формата
+// (например, zero width joiner) и управляющие символы — ноль, остальные — одну.
+func displayWidth(s string) int {
+	total := 0
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc):
+		case isWideRune(r):
+			total += 2
+		default:
+			total++
+		}
+	}
+	return total
+}
+
+// isWideRune сообщает, занимает ли символ две колонки терминала.
+func isWideRune(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByMetric(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		metric   string
+		expected string
+	}{
+		{
+			name:     "shortlex by runes",
+			input:    "bb\nccc\nab\nc",
+			metric:   "runes",
+			expected: "c\nab\nbb\nccc",
+		},
+		{
+			name:     "bytes count utf-8 length",
+			input:    "ё\naa\na",
+			metric:   "bytes",
+			expected: "a\naa\nё",
+		},
+		{
+			name:     "runes count characters",
+			input:    "aa\nё\na",
+			metric:   "runes",
+			expected: "a\nё\naa",
+		},
+		{
+			name:     "display width of wide characters",
+			input:    "日本\nabc\nab",
+			metric:   "width",
+			expected: "ab\nabc\n日本",
+		},
+		{
+			name:     "combining marks have zero width",
+			input:    "abc\ne\u0301e\u0301",
+			metric:   "width",
+			expected: "e\u0301e\u0301\nabc",
+		},
+		{
+			name:     "word count",
+			input:    "a b c\nsingle\none two",
+			metric:   "words",
+			expected: "single\none two\na b c",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			metric:   "runes",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByMetric(tt.input, identity, domain.SortOptions{Metric: tt.metric})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{name: "ascii", input: "hello", expected: 5},
+		{name: "cyrillic", input: "привет", expected: 6},
+		{name: "cjk", input: "日本語", expected: 6},
+		{name: "fullwidth latin", input: "ＡＢ", expected: 4},
+		{name: "combining acute", input: "e\u0301", expected: 1},
+		{name: "zero width joiner", input: "a\u200db", expected: 2},
+		{name: "tab is control", input: "a\tb", expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, displayWidth(tt.input))
+		})
+	}
+}
+
+func TestValidateMetric(t *testing.T) {
+	for _, metric := range []string{"", "bytes", "runes", "width", "words"} {
+		require.NoError(t, validateMetric(metric))
+	}
+	require.ErrorIs(t, validateMetric("lines"), domain.ErrInvalidMetric)
+}
+
+func TestSortTiebreakRequiresKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    domain.SortOptions
+		wantErr error
+	}{
+		{name: "with -k", opts: domain.SortOptions{KeySpecs: []string{"1"}, Tiebreak: "bytes"}},
+		{name: "with typed keys", opts: domain.SortOptions{KeySpecs: []string{"1:n", "2"}, Tiebreak: "bytes"}},
+		{name: "with key regex", opts: domain.SortOptions{KeyRegex: []string{`\w+`}, Tiebreak: "runes"}},
+		{name: "with order by", opts: domain.SortOptions{Header: 1, OrderBy: "name", Tiebreak: "width"}},
+		{name: "without keys", opts: domain.SortOptions{Tiebreak: "bytes"}, wantErr: domain.ErrConflictOpts},
+		{name: "with numeric only", opts: domain.SortOptions{Numeric: true, Tiebreak: "bytes"}, wantErr: domain.ErrConflictOpts},
+		{name: "with stat key", opts: domain.SortOptions{KeySpecs: []string{"1"}, StatKey: "size", Tiebreak: "bytes"}, wantErr: domain.ErrConflictOpts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Sort("name\nb\na", tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,37 +1,67 @@
 package usecase
 
 import (
-	"regexp"
 	"sort"
 	"strings"
+	"unicode"
 	"unix_sort_lite/internal/domain"
 )
 
-var (
-	monthRegex = regexp.MustCompile(`(?i)^\s*(jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)\s*$`)
+// defaultLocale используется, если локаль не задана или для нее нет таблицы названий.
+const defaultLocale = "en"
 
-	// monthOrder определяет порядок месяцев в году для сортировки
-	// Соответствует календарному порядку: январь (1) → декабрь (12)
-	monthOrder = map[string]int{
-		"jan": 1, "feb": 2, "mar": 3, "apr": 4,
-		"may": 5, "jun": 6, "jul": 7, "aug": 8,
-		"sep": 9, "oct": 10, "nov": 11, "dec": 12,
-	}
-)
+// monthNames определяет названия месяцев по локалям и их порядок в году:
+// январь (1) → декабрь (12). Для каждой локали перечислены полные названия,
+// сокращения и распространенные варианты, для русского — также родительный
+// падеж ("18 марта") и предложный ("в мае").
+var monthNames = map[string]map[string]int{
+	"en": {
+		"jan": 1, "january": 1,
+		"feb": 2, "february": 2,
+		"mar": 3, "march": 3,
+		"apr": 4, "april": 4,
+		"may": 5,
+		"jun": 6, "june": 6,
+		"jul": 7, "july": 7,
+		"aug": 8, "august": 8,
+		"sep": 9, "sept": 9, "september": 9,
+		"oct": 10, "october": 10,
+		"nov": 11, "november": 11,
+		"dec": 12, "december": 12,
+	},
+	"ru": {
+		"янв": 1, "январь": 1, "января": 1, "январе": 1,
+		"фев": 2, "февр": 2, "февраль": 2, "февраля": 2, "феврале": 2,
+		"мар": 3, "март": 3, "марта": 3, "марте": 3,
+		"апр": 4, "апрель": 4, "апреля": 4, "апреле": 4,
+		"май": 5, "мая": 5, "мае": 5,
+		"июн": 6, "июнь": 6, "июня": 6, "июне": 6,
+		"июл": 7, "июль": 7, "июля": 7, "июле": 7,
+		"авг": 8, "август": 8, "августа": 8, "августе": 8,
+		"сен": 9, "сент": 9, "сентябрь": 9, "сентября": 9, "сентябре": 9,
+		"окт": 10, "октябрь": 10, "октября": 10, "октябре": 10,
+		"ноя": 11, "нояб": 11, "ноябрь": 11, "ноября": 11, "ноябре": 11,
+		"дек": 12, "декабрь": 12, "декабря": 12, "декабре": 12,
+	},
+}
 
 // SortByMonth выполняет сортировку по месяцам (флаг -M в Unix sort).
-// Распознает сокращенные названия месяцев (Jan, Feb, Mar, ...) и сортирует их
-// в календарном порядке. Строки, не содержащие месяцы, сортируются
-// лексикографически и идут первыми.
+// Распознает название месяца в начале строки (полное, сокращенное или в падежной форме)
+// и сортирует строки в календарном порядке. Названия берутся из таблицы локали
+// --month-locale (или LC_TIME); английские названия распознаются в любой локали.
+// Строки, не начинающиеся с месяца, сортируются лексикографически и идут первыми.
 //
 // Примеры:
 //
 //	"Feb\nJan\nMar" → "Jan\nFeb\nMar"
+//	"March 2026\nJanuary 2026" → "January 2026\nMarch 2026"
+//	"марта\nянв" с локалью ru → "янв\nмарта"
 //	"abc\nFeb\nxyz\nJan" → "abc\nxyz\nJan\nFeb" (не-месяцы первыми)
 func SortByMonth(s string, modify func(string) string, opts domain.SortOptions) string {
+	months := calendarNames(monthNames, opts.MonthLocale)
 	rows := strings.Split(s, "\n")
 	sort.SliceStable(rows, func(i, j int) bool {
-		return compareMonthStrings(modify(rows[i]), modify(rows[j]))
+		return compareMonthStrings(modify(rows[i]), modify(rows[j]), months)
 	})
 	return strings.Join(rows, "\n")
 }
@@ -39,18 +69,68 @@ func SortByMonth(s string, modify func(string) string, opts domain.SortOptions)
 // compareMonthStrings сравнивает две строки по правилам месячной сортировки.
 // Примеры порядка:
 //
-//	"abc" < "xyz" < "Jan" < "Feb" < "Mar" < ... < "Dec"
-func compareMonthStrings(iStr, jStr string) bool {
-	iMatch, jMatch := monthRegex.FindStringSubmatch(strings.ToLower(iStr)), monthRegex.FindStringSubmatch(strings.ToLower(jStr))
+//	"abc" < "xyz" < "Jan" < "Feb 2026" < "March" < ... < "Dec"
+func compareMonthStrings(iStr, jStr string, months map[string]int) bool {
+	return compareCalendarStrings(iStr, jStr, months)
+}
+
+// compareCalendarStrings сравнивает строки по порядковому номеру названия в начале строки.
+// Общая логика для месяцев и дней недели: строки без названия идут первыми
+// и сравниваются лексикографически.
+func compareCalendarStrings(iStr, jStr string, names map[string]int) bool {
+	iOrder, iOk := matchCalendarName(iStr, names)
+	jOrder, jOk := matchCalendarName(jStr, names)
 
 	switch {
-	case len(iMatch) == 2 && len(jMatch) == 2:
-		return monthOrder[iMatch[1]] < monthOrder[jMatch[1]]
-	case len(iMatch) == 2 && len(jMatch) != 2:
+	case iOk && jOk:
+		return iOrder < jOrder
+	case iOk && !jOk:
 		return false
-	case len(iMatch) != 2 && len(jMatch) == 2:
+	case !iOk && jOk:
 		return true
 	default:
 		return iStr < jStr
 	}
 }
+
+// matchCalendarName ищет название в начале строки: пропускает ведущие пробелы
+// и берет первое слово из букв без учета регистра, поэтому "Mar 2026", "Sept." и
+// "марта 2026" распознаются, а "data-Feb" — нет.
+func matchCalendarName(s string, names map[string]int) (int, bool) {
+	s = strings.TrimLeftFunc(s, unicode.IsSpace)
+	end := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) })
+	if end < 0 {
+		end = len(s)
+	}
+	order, ok := names[strings.ToLower(s[:end])]
+	return order, ok
+}
+
+// calendarNames возвращает таблицу названий для локали вида "ru", "ru_RU" или "ru_RU.UTF-8",
+// объединенную с английской. Для неизвестной локали, "C" и "POSIX" используется английская.
+func calendarNames(tables map[string]map[string]int, locale string) map[string]int {
+	names := tables[defaultLocale]
+	table, ok := tables[localeLanguage(locale)]
+	if !ok || localeLanguage(locale) == defaultLocale {
+		return names
+	}
+
+	merged := make(map[string]int, len(names)+len(table))
+	for name, order := range names {
+		merged[name] = order
+	}
+	for name, order := range table {
+		merged[name] = order
+	}
+	return merged
+}
+
+// localeLanguage выделяет код языка из имени локали POSIX или тега BCP 47:
+// "ru_RU.UTF-8" → "ru", "en-US" → "en", "C" → "c".
+func localeLanguage(locale string) string {
+	end := strings.IndexAny(locale, "_-.@")
+	if end < 0 {
+		end = len(locale)
+	}
+	return strings.ToLower(locale[:end])
+}
@@ -46,9 +46,9 @@ func TestSortByMonth(t *testing.T) {
 			expected: "event Feb\nmeeting Mar\nparty Jan",
 		},
 		{
-			name:     "partial month names",
-			input:    "January\nFebruary\nMarch",
-			expected: "February\nJanuary\nMarch",
+			name:     "full month names",
+			input:    "March\nJanuary\nFebruary",
+			expected: "January\nFebruary\nMarch",
 		},
 		{
 			name:     "empty input",
@@ -95,6 +95,21 @@ func TestSortByMonth(t *testing.T) {
 			input:    "Nov\nSep\nOct",
 			expected: "Sep\nOct\nNov",
 		},
+		{
+			name:     "month at start of key",
+			input:    "Mar 2026\nJan 2027\nFeb 15",
+			expected: "Jan 2027\nFeb 15\nMar 2026",
+		},
+		{
+			name:     "common variants",
+			input:    "Sept.\nAugust\nsep 1",
+			expected: "August\nSept.\nsep 1",
+		},
+		{
+			name:     "russian names ignored in english locale",
+			input:    "Feb\nмарта\nянв",
+			expected: "марта\nянв\nFeb",
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,12 +169,80 @@ func TestCompareMonthStrings(t *testing.T) {
 			b:        "Jan",
 			expected: false,
 		},
+		{
+			name:     "abbreviation equals full name",
+			a:        "January",
+			b:        "jan",
+			expected: false,
+		},
+		{
+			name:     "leading blanks skipped",
+			a:        "  Nov 1",
+			b:        "December",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareMonthStrings(tt.a, tt.b, monthNames["en"])
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSortByMonthLocale(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		locale   string
+		expected string
+	}{
+		{
+			name:     "russian nominative",
+			input:    "март\nянварь\nдекабрь",
+			locale:   "ru",
+			expected: "январь\nмарт\nдекабрь",
+		},
+		{
+			name:     "russian genitive in dates",
+			input:    "марта 2026\n18 октября\nянваря 2026\nМая",
+			locale:   "ru_RU.UTF-8",
+			expected: "18 октября\nянваря 2026\nмарта 2026\nМая",
+		},
+		{
+			name:     "russian abbreviations",
+			input:    "дек.\nфевр.\nсент.",
+			locale:   "ru",
+			expected: "февр.\nсент.\nдек.",
+		},
+		{
+			name:     "english names in russian locale",
+			input:    "март\nFeb\nJanuary",
+			locale:   "ru",
+			expected: "January\nFeb\nмарт",
+		},
+		{
+			name:     "unknown locale falls back to english",
+			input:    "март\nFeb\nJan",
+			locale:   "de_DE.UTF-8",
+			expected: "март\nJan\nFeb",
+		},
+		{
+			name:     "posix locale",
+			input:    "Feb\nJan",
+			locale:   "C",
+			expected: "Jan\nFeb",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := compareMonthStrings(tt.a, tt.b)
+			result := SortByMonth(tt.input, identity, domain.SortOptions{MonthLocale: tt.locale})
 			require.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// customOrder хранит пользовательский порядок значений (флаги --order и --order-file).
+type customOrder struct {
+	rank         map[string]int
+	ignoreCase   bool
+	unknownFirst bool
+}
+
+// newCustomOrder строит ранги значений по их позиции в списке.
+// При повторе значения учитывается первое вхождение.
+func newCustomOrder(opts domain.SortOptions) (customOrder, error) {
+	order := customOrder{
+		rank:       make(map[string]int, len(opts.Order)),
+		ignoreCase: opts.OrderIgnoreCase,
+	}
+
+	switch opts.OrderUnknown {
+	case "", domain.PlaceLast:
+	case domain.PlaceFirst:
+		order.unknownFirst = true
+	default:
+		return order, domain.ErrInvalidPlace
+	}
+
+	for i, value := range opts.Order {
+		value = order.normalize(value)
+		if _, ok := order.rank[value]; !ok {
+			order.rank[value] = i
+		}
+	}
+	return order, nil
+}
+
+// normalize приводит значение к виду, в котором оно ищется в списке.
+func (o customOrder) normalize(s string) string {
+	s = strings.TrimSpace(s)
+	if o.ignoreCase {
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// lookup возвращает позицию значения в пользовательском списке.
+func (o customOrder) lookup(s string) (int, bool) {
+	rank, ok := o.rank[o.normalize(s)]
+	return rank, ok
+}
+
+// SortByOrder выполняет сортировку по пользовательскому списку значений (флаги --order, --order-file).
+// Строки упорядочиваются по позиции значения в списке, как уровни логирования или приоритеты.
+// Значения вне списка сортируются лексикографически и по умолчанию идут последними,
+// флаг --order-unknown=first переносит их в начало. С --order-ignore-case регистр не учитывается.
+//
+// Примеры (список "DEBUG,INFO,WARN,ERROR"):
+//
+//	"ERROR\nDEBUG\nWARN" → "DEBUG\nWARN\nERROR"
+//	"TRACE\nINFO\nAUDIT" → "INFO\nAUDIT\nTRACE" (неизвестные значения последними)
+func SortByOrder(s string, modify func(string) string, opts domain.SortOptions) string {
+	order, _ := newCustomOrder(opts) // размещение проверяется в Sort
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareOrderStrings(modify(rows[i]), modify(rows[j]), order)
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareOrderStrings сравнивает две строки по позиции в пользовательском списке.
+//
+// Примеры порядка (список "low,medium,high", неизвестные последними):
+//
+//	"low" < "medium" < "high" < "abc" < "xyz"
+func compareOrderStrings(iStr, jStr string, order customOrder) bool {
+	iRank, iOk := order.lookup(iStr)
+	jRank, jOk := order.lookup(jStr)
+
+	switch {
+	case iOk && jOk:
+		return iRank < jRank
+	case iOk && !jOk:
+		return !order.unknownFirst
+	case !iOk && jOk:
+		return order.unknownFirst
+	default:
+		return iStr < jStr
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByOrder(t *testing.T) {
+	identity := func(s string) string { return s }
+	levels := []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "severity levels",
+			input:    "ERROR\nDEBUG\nFATAL\nWARN\nINFO",
+			opts:     domain.SortOptions{Order: levels},
+			expected: "DEBUG\nINFO\nWARN\nERROR\nFATAL",
+		},
+		{
+			name:     "unknown values last by default",
+			input:    "TRACE\nINFO\nAUDIT\nDEBUG",
+			opts:     domain.SortOptions{Order: levels},
+			expected: "DEBUG\nINFO\nAUDIT\nTRACE",
+		},
+		{
+			name:     "unknown values first",
+			input:    "TRACE\nINFO\nAUDIT\nDEBUG",
+			opts:     domain.SortOptions{Order: levels, OrderUnknown: domain.PlaceFirst},
+			expected: "AUDIT\nTRACE\nDEBUG\nINFO",
+		},
+		{
+			name:     "case sensitive by default",
+			input:    "error\nINFO",
+			opts:     domain.SortOptions{Order: levels},
+			expected: "INFO\nerror",
+		},
+		{
+			name:     "ignore case",
+			input:    "error\nInfo\ndebug",
+			opts:     domain.SortOptions{Order: levels, OrderIgnoreCase: true},
+			expected: "debug\nInfo\nerror",
+		},
+		{
+			name:     "surrounding blanks ignored",
+			input:    " high\nlow \nmedium",
+			opts:     domain.SortOptions{Order: []string{"low", "medium", "high", "critical"}},
+			expected: "low \nmedium\n high",
+		},
+		{
+			name:     "duplicate list entries use first position",
+			input:    "b\na",
+			opts:     domain.SortOptions{Order: []string{"a", "b", "a"}},
+			expected: "a\nb",
+		},
+		{
+			name:     "equal values keep input order",
+			input:    "prod 2\nstage\nprod 1",
+			opts:     domain.SortOptions{Order: []string{"stage", "prod 2", "prod 1"}},
+			expected: "stage\nprod 2\nprod 1",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			opts:     domain.SortOptions{Order: levels},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByOrder(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestNewCustomOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		unknown string
+		wantErr bool
+	}{
+		{name: "default placement", unknown: ""},
+		{name: "first", unknown: domain.PlaceFirst},
+		{name: "last", unknown: domain.PlaceLast},
+		{name: "invalid placement", unknown: "middle", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := newCustomOrder(domain.SortOptions{Order: []string{"a"}, OrderUnknown: tt.unknown})
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrInvalidPlace)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
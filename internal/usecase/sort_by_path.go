@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// pathOrder задает правила сравнения путей (флаги --dirs-first и --path-version).
+type pathOrder struct {
+	dirsFirst bool
+	version   bool
+}
+
+// pathKey представляет путь, разобранный на компоненты.
+type pathKey struct {
+	absolute   bool
+	components []string
+	trailing   bool // путь заканчивается на '/', последний компонент — каталог
+}
+
+// SortByPath выполняет сортировку путей по компонентам (флаг --path-sort).
+// Пути сравниваются компонент за компонентом, а не побайтово, поэтому '/' не
+// сравнивается с другими символами и содержимое каталога идет сразу за ним:
+// "a/b.txt" < "a-b/". Абсолютные пути идут перед относительными, префикс "./" не учитывается.
+// С флагом --dirs-first каталоги на одном уровне идут перед файлами; каталогом считается
+// компонент, за которым следуют другие, или путь с завершающим '/'. С флагом
+// --path-version компоненты сравниваются как версии: "file2" < "file10".
+//
+// Примеры:
+//
+//	"a-b/\na/b.txt\na.txt" → "a/b.txt\na-b/\na.txt"
+//	"z.txt\na/b.txt" с --dirs-first → "a/b.txt\nz.txt"
+//	"v10/x\nv2/x" с --path-version → "v2/x\nv10/x"
+func SortByPath(s string, modify func(string) string, opts domain.SortOptions) string {
+	order := pathOrder{dirsFirst: opts.DirsFirst, version: opts.PathVersion}
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return comparePathStrings(modify(rows[i]), modify(rows[j]), order)
+	})
+	return strings.Join(rows, "\n")
+}
+
+// comparePathStrings сравнивает две строки как пути.
+//
+// Примеры порядка (с --dirs-first):
+//
+//	/etc < a/ < a/b/c.txt < a/z.txt < a.txt < b.txt
+func comparePathStrings(iStr, jStr string, order pathOrder) bool {
+	iPath, jPath := parsePath(iStr), parsePath(jStr)
+	if iPath.absolute != jPath.absolute {
+		return iPath.absolute
+	}
+
+	n := min(len(iPath.components), len(jPath.components))
+	for k := 0; k < n; k++ {
+		iDir, jDir := iPath.isDir(k), jPath.isDir(k)
+		if order.dirsFirst && iDir != jDir {
+			return iDir
+		}
+
+		var cmp int
+		if order.version {
+			cmp = compareVersion(iPath.components[k], jPath.components[k])
+		} else {
+			cmp = strings.Compare(iPath.components[k], jPath.components[k])
+		}
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+
+	// Общий префикс совпал: родительский каталог идет перед содержимым
+	return len(iPath.components) < len(jPath.components)
+}
+
+// parsePath разбирает путь на компоненты, отбрасывая пустые компоненты и "./" в начале.
+func parsePath(s string) pathKey {
+	s = strings.TrimSpace(s)
+	key := pathKey{
+		absolute: strings.HasPrefix(s, "/"),
+		trailing: strings.HasSuffix(s, "/"),
+	}
+	for _, component := range strings.Split(s, "/") {
+		if component != "" && !(component == "." && len(key.components) == 0) {
+			key.components = append(key.components, component)
+		}
+	}
+	return key
+}
+
+// isDir сообщает, является ли k-й компонент каталогом.
+func (p pathKey) isDir(k int) bool {
+	return k < len(p.components)-1 || p.trailing
+}
+
+// compareVersion сравнивает строки как версии: последовательности цифр сравниваются
+// как числа, остальные части — побайтово. При равенстве по этим правилам ("1.01" и "1.1")
+// строки сравниваются побайтово.
+//
+// Примеры:
+//
+//	compareVersion("file2", "file10") → -1
+//	compareVersion("1.10", "1.9") → 1
+func compareVersion(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			iEnd, jEnd := digitsEnd(a, i), digitsEnd(b, j)
+			iNum := strings.TrimLeft(a[i:iEnd], "0")
+			jNum := strings.TrimLeft(b[j:jEnd], "0")
+			if len(iNum) != len(jNum) {
+				if len(iNum) < len(jNum) {
+					return -1
+				}
+				return 1
+			}
+			if cmp := strings.Compare(iNum, jNum); cmp != 0 {
+				return cmp
+			}
+			i, j = iEnd, jEnd
+			continue
+		}
+		if a[i] != b[j] {
+			if a[i] < b[j] {
+				return -1
+			}
+			return 1
+		}
+		i++
+		j++
+	}
+
+	switch {
+	case len(a)-i < len(b)-j:
+		return -1
+	case len(a)-i > len(b)-j:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// digitsEnd возвращает индекс конца последовательности цифр, начинающейся с позиции start.
+func digitsEnd(s string, start int) int {
+	end := start
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	return end
+}
+
+// isDigit сообщает, является ли байт ASCII-цифрой.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByPath(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "slash is not a byte",
+			input:    "a-b/\na/b.txt\na.txt",
+			expected: "a/b.txt\na-b/\na.txt",
+		},
+		{
+			name:     "parent before children",
+			input:    "a/b/c\na/b\na",
+			expected: "a\na/b\na/b/c",
+		},
+		{
+			name:     "find output",
+			input:    "./src/main.go\n./src-old/main.go\n./src/a/b.go\n./README",
+			expected: "./README\n./src/a/b.go\n./src/main.go\n./src-old/main.go",
+		},
+		{
+			name:     "absolute before relative",
+			input:    "etc/hosts\n/etc/hosts",
+			expected: "/etc/hosts\netc/hosts",
+		},
+		{
+			name:     "dirs first",
+			input:    "z.txt\na.txt\nb/c.txt\nb/a/x.txt",
+			opts:     domain.SortOptions{DirsFirst: true},
+			expected: "b/a/x.txt\nb/c.txt\na.txt\nz.txt",
+		},
+		{
+			name:     "trailing slash marks directory",
+			input:    "lib.txt\nlib/\nbin",
+			opts:     domain.SortOptions{DirsFirst: true},
+			expected: "lib/\nbin\nlib.txt",
+		},
+		{
+			name:     "version components",
+			input:    "v10/x\nv2/x\nv1.10/x\nv1.9/x",
+			opts:     domain.SortOptions{PathVersion: true},
+			expected: "v1.9/x\nv1.10/x\nv2/x\nv10/x",
+		},
+		{
+			name:     "version file names",
+			input:    "img/file10.png\nimg/file2.png\nimg/file1.png",
+			opts:     domain.SortOptions{PathVersion: true},
+			expected: "img/file1.png\nimg/file2.png\nimg/file10.png",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByPath(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+	}{
+		{name: "numbers compare numerically", a: "file2", b: "file10", expected: -1},
+		{name: "dotted versions", a: "1.10", b: "1.9", expected: 1},
+		{name: "leading zeros", a: "1.01", b: "1.1", expected: -1},
+		{name: "equal", a: "v1.2", b: "v1.2", expected: 0},
+		{name: "prefix shorter first", a: "v1", b: "v1-rc", expected: -1},
+		{name: "letters bytewise", a: "a1", b: "b1", expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, compareVersion(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// regexKeyGroup — имя группы, из которой берется ключ, если в выражении несколько групп.
+const regexKeyGroup = "key"
+
+// regexKey извлекает ключ из строки по регулярному выражению (флаг --key-regex).
+type regexKey struct {
+	re    *regexp.Regexp
+	group int // номер группы с ключом, 0 — совпадение целиком
+}
+
+// newRegexKeys компилирует выражения --key-regex. Ключом служит именованная группа
+// (?P<key>...), иначе первая группа, а в выражении без групп — совпадение целиком.
+func newRegexKeys(opts domain.SortOptions) ([]regexKey, error) {
+	switch opts.RegexMissing {
+	case "", domain.PlaceFirst, domain.PlaceLast, domain.PlaceError:
+	default:
+		return nil, domain.ErrInvalidPlace
+	}
+
+	keys := make([]regexKey, 0, len(opts.KeyRegex))
+	for _, pattern := range opts.KeyRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidRegex, err)
+		}
+		key := regexKey{re: re}
+		if named := re.SubexpIndex(regexKeyGroup); named > 0 {
+			key.group = named
+		} else if re.NumSubexp() > 0 {
+			key.group = 1
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// extract возвращает ключ строки и признак совпадения.
+func (k regexKey) extract(line string) (string, bool) {
+	match := k.re.FindStringSubmatchIndex(line)
+	if match == nil || match[2*k.group] < 0 {
+		return "", false
+	}
+	return line[match[2*k.group]:match[2*k.group+1]], true
+}
+
+// regexRow хранит строку и извлеченные из нее ключи.
+type regexRow struct {
+	line    string
+	keys    []string
+	matched bool
+}
+
+// SortByRegex выполняет сортировку по ключам, извлеченным регулярными выражениями (флаг --key-regex).
+// Каждое выражение задает очередной ключ: при равенстве первого сравнивается второй и т.д.
+// Ключи сравниваются по типу сортировки (-n, -h, -M, --date-sort, ...), как поля -k.
+// Строки, в которых не совпало хотя бы одно выражение, по умолчанию идут последними
+// в исходном порядке, --regex-missing=first переносит их в начало, а --regex-missing=error
+// завершает сортировку ошибкой domain.ErrNoRegexMatch (кроме пустых строк).
+// Флаг -r меняет только порядок ключей, размещение несовпавших строк от него не зависит.
+//
+// Примеры:
+//
+//	"took 123ms\ntook 45ms" с --key-regex 'took (\d+)ms' -n → "took 45ms\ntook 123ms"
+//	"user=bob\nuser=alice" с --key-regex 'user=(?P<key>\w+)' → "user=alice\nuser=bob"
+func SortByRegex(s string, modify func(string) string, opts domain.SortOptions) (string, error) {
+	keys, _ := newRegexKeys(opts) // выражения проверяются в Sort
+	less := keyComparator(opts)
+	missingFirst := opts.RegexMissing == domain.PlaceFirst
+
+	lines := strings.Split(s, "\n")
+	rows := make([]regexRow, len(lines))
+	for i, line := range lines {
+		row := regexRow{line: line, keys: make([]string, len(keys)), matched: true}
+		source := line
+		if opts.StripANSI {
+			source = stripANSI(line)
+		}
+		for k, key := range keys {
+			value, ok := key.extract(source)
+			if !ok {
+				// Пустые строки (в том числе завершающий перевод строки) не считаются записями
+				if opts.RegexMissing == domain.PlaceError && line != "" {
+					return "", fmt.Errorf("%w: %q", domain.ErrNoRegexMatch, line)
+				}
+				row.matched = false
+				break
+			}
+			row.keys[k] = modify(value)
+		}
+		rows[i] = row
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].matched != rows[j].matched {
+			return rows[i].matched != missingFirst
+		}
+		if !rows[i].matched {
+			return false
+		}
+		for k := range keys {
+			iKey, jKey := rows[i].keys[k], rows[j].keys[k]
+			if opts.Reverse {
+				iKey, jKey = jKey, iKey
+			}
+			if less(iKey, jKey) {
+				return true
+			}
+			if less(jKey, iKey) {
+				return false
+			}
+		}
+		if opts.Tiebreak == "" {
+			return false
+		}
+		// Ключи равны: сравнение строк целиком по метрике (флаг --tiebreak-metric)
+		iMetric, jMetric := lineMetric(modify(rows[i].line), opts.Tiebreak), lineMetric(modify(rows[j].line), opts.Tiebreak)
+		if opts.Reverse {
+			return jMetric < iMetric
+		}
+		return iMetric < jMetric
+	})
+
+	result := make([]string, len(rows))
+	for i, row := range rows {
+		result[i] = row.line
+	}
+	return strings.Join(result, "\n"), nil
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByRegex(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "numeric key from capture group",
+			input:    "user=alice took 123ms\nuser=bob took 45ms\nuser=eve took 9ms",
+			opts:     domain.SortOptions{KeyRegex: []string{`took (\d+)ms`}, Numeric: true},
+			expected: "user=eve took 9ms\nuser=bob took 45ms\nuser=alice took 123ms",
+		},
+		{
+			name:     "reverse keeps unmatched lines last",
+			input:    "took 5ms\nx\ntook 1ms\n",
+			opts:     domain.SortOptions{KeyRegex: []string{`took (\d+)ms`}, Numeric: true, Reverse: true},
+			expected: "took 5ms\ntook 1ms\nx\n",
+		},
+		{
+			name:     "named group wins over first group",
+			input:    "(a) user=bob\n(b) user=alice",
+			opts:     domain.SortOptions{KeyRegex: []string{`\((\w)\) user=(?P<key>\w+)`}},
+			expected: "(b) user=alice\n(a) user=bob",
+		},
+		{
+			name:     "whole match without groups",
+			input:    "x 2G\ny 512M\nz 1K",
+			opts:     domain.SortOptions{KeyRegex: []string{`\d+[KMG]`}, HumanNumeric: true},
+			expected: "z 1K\ny 512M\nx 2G",
+		},
+		{
+			name:     "month key",
+			input:    "backup of Mar done\nbackup of Jan done",
+			opts:     domain.SortOptions{KeyRegex: []string{`of (\w+)`}, Month: true},
+			expected: "backup of Jan done\nbackup of Mar done",
+		},
+		{
+			name:  "second regex breaks ties",
+			input: "svc=api took 5ms\nsvc=db took 5ms\nsvc=api took 1ms",
+			opts: domain.SortOptions{
+				KeyRegex: []string{`took (\d+)ms`, `svc=(\w+)`},
+				Numeric:  true,
+			},
+			expected: "svc=api took 1ms\nsvc=api took 5ms\nsvc=db took 5ms",
+		},
+		{
+			name:     "unmatched lines last in input order",
+			input:    "noise b\ntook 2ms\nnoise a\ntook 1ms",
+			opts:     domain.SortOptions{KeyRegex: []string{`took (\d+)ms`}, Numeric: true},
+			expected: "took 1ms\ntook 2ms\nnoise b\nnoise a",
+		},
+		{
+			name:  "unmatched lines first",
+			input: "took 2ms\nnoise\ntook 1ms",
+			opts: domain.SortOptions{
+				KeyRegex:     []string{`took (\d+)ms`},
+				Numeric:      true,
+				RegexMissing: domain.PlaceFirst,
+			},
+			expected: "noise\ntook 1ms\ntook 2ms",
+		},
+		{
+			name:     "ansi stripped before matching",
+			input:    "took \x1b[31m20\x1b[0mms\ntook 3ms",
+			opts:     domain.SortOptions{KeyRegex: []string{`took (\d+)ms`}, Numeric: true, StripANSI: true},
+			expected: "took 3ms\ntook \x1b[31m20\x1b[0mms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := SortByRegex(tt.input, identity, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSortByRegexErrors(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	t.Run("unmatched line with error policy", func(t *testing.T) {
+		t.Parallel()
+		opts := domain.SortOptions{KeyRegex: []string{`took (\d+)ms`}, RegexMissing: domain.PlaceError}
+		_, err := SortByRegex("took 1ms\nnoise", identity, opts)
+		require.ErrorIs(t, err, domain.ErrNoRegexMatch)
+	})
+
+	t.Run("trailing newline with error policy", func(t *testing.T) {
+		t.Parallel()
+		opts := domain.SortOptions{KeyRegex: []string{`took (\d+)ms`}, Numeric: true, RegexMissing: domain.PlaceError}
+		result, err := SortByRegex("took 5ms\ntook 1ms\n", identity, opts)
+		require.NoError(t, err)
+		require.Equal(t, "took 1ms\ntook 5ms\n", result)
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		t.Parallel()
+		_, err := newRegexKeys(domain.SortOptions{KeyRegex: []string{`took (\d+ms`}})
+		require.ErrorIs(t, err, domain.ErrInvalidRegex)
+	})
+
+	t.Run("invalid placement", func(t *testing.T) {
+		t.Parallel()
+		_, err := newRegexKeys(domain.SortOptions{KeyRegex: []string{`\d+`}, RegexMissing: "middle"})
+		require.ErrorIs(t, err, domain.ErrInvalidPlace)
+	})
+}
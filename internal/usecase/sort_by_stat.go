@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"time"
+	"unix_sort_lite/internal/domain"
+)
+
+// Атрибуты файла для флага --stat-key
+const (
+	statSize  = "size"
+	statMtime = "mtime"
+	statAtime = "atime"
+	statCtime = "ctime"
+	statMode  = "mode"
+	statOwner = "owner"
+)
+
+// fileStat содержит метаданные файла, по которым возможна сортировка.
+type fileStat struct {
+	size  int64
+	mtime time.Time
+	atime time.Time
+	ctime time.Time
+	mode  os.FileMode
+	owner string
+}
+
+// statEntry хранит результат stat для строки: метаданные или признак отсутствия файла.
+type statEntry struct {
+	path  string
+	stat  fileStat
+	found bool
+}
+
+// validateStatOptions проверяет значения флагов --stat-key и --stat-missing.
+func validateStatOptions(opts domain.SortOptions) error {
+	switch opts.StatKey {
+	case "", statSize, statMtime, statAtime, statCtime, statMode, statOwner:
+	default:
+		return fmt.Errorf("%w: %q", domain.ErrInvalidStatKey, opts.StatKey)
+	}
+
+	switch opts.StatMissing {
+	case "", domain.PlaceFirst, domain.PlaceLast, domain.PlaceError:
+		return nil
+	default:
+		return domain.ErrInvalidPlace
+	}
+}
+
+// SortByStat выполняет сортировку путей по метаданным файлов (флаг --stat-key).
+// Каждая строка (или поле -k N) считается путем к файлу, для которого выполняется stat;
+// строки сортируются по размеру, времени изменения, доступа или изменения inode,
+// режиму доступа или имени владельца. Файлы с равными атрибутами сохраняют исходный порядок.
+//
+// Отсутствующие файлы по умолчанию идут последними в лексикографическом порядке,
+// --stat-missing=first переносит их в начало, а --stat-missing=error завершает
+// сортировку ошибкой domain.ErrMissingFile. Строки без пути всегда идут последними.
+// Флаг -r меняет только порядок атрибутов существующих файлов.
+//
+// Примеры:
+//
+//	"big.iso\nsmall.txt" с --stat-key=size → "small.txt\nbig.iso"
+//	"new.log\nold.log" с --stat-key=mtime → "old.log\nnew.log"
+func SortByStat(s string, modify func(string) string, opts domain.SortOptions) (string, error) {
+	rows := strings.Split(s, "\n")
+	entries := make(map[string]statEntry, len(rows))
+	owners := make(map[uint32]string)
+
+	for _, row := range rows {
+		path := modify(row)
+		if opts.Key {
+			path = ""
+			if fields := lineFields(row, opts); len(fields) >= opts.Field {
+				path = modify(fields[opts.Field-1])
+			}
+		}
+
+		entry := statEntry{path: path}
+		if path == "" {
+			// Пустая строка (в том числе завершающий перевод строки) не является путем
+			entries[row] = entry
+			continue
+		}
+		// Символические ссылки разыменовываются
+		if info, err := os.Stat(path); err == nil {
+			entry.stat, entry.found = newFileStat(info, owners), true
+		} else if opts.StatMissing == domain.PlaceError {
+			return "", fmt.Errorf("%w: %w", domain.ErrMissingFile, err)
+		}
+		entries[row] = entry
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareStatEntries(entries[rows[i]], entries[rows[j]], opts)
+	})
+	return strings.Join(rows, "\n"), nil
+}
+
+// compareStatEntries сравнивает записи по атрибуту --stat-key; с -r порядок атрибутов
+// обратный, а размещение отсутствующих файлов и строк без пути от -r не зависит.
+func compareStatEntries(i, j statEntry, opts domain.SortOptions) bool {
+	missingFirst := opts.StatMissing == domain.PlaceFirst
+	switch {
+	case i.path == "" || j.path == "":
+		return i.path != "" && j.path == ""
+	case i.found && j.found:
+		if opts.Reverse {
+			return compareFileStats(j.stat, i.stat, opts.StatKey)
+		}
+		return compareFileStats(i.stat, j.stat, opts.StatKey)
+	case i.found && !j.found:
+		return !missingFirst
+	case !i.found && j.found:
+		return missingFirst
+	default:
+		return i.path < j.path
+	}
+}
+
+// compareFileStats сравнивает метаданные двух файлов по атрибуту key.
+func compareFileStats(i, j fileStat, key string) bool {
+	switch key {
+	case statMtime:
+		return i.mtime.Before(j.mtime)
+	case statAtime:
+		return i.atime.Before(j.atime)
+	case statCtime:
+		return i.ctime.Before(j.ctime)
+	case statMode:
+		return i.mode < j.mode
+	case statOwner:
+		return i.owner < j.owner
+	default:
+		return i.size < j.size
+	}
+}
+
+// newFileStat собирает метаданные из os.FileInfo. Время доступа, время изменения inode
+// и владелец берутся из системной структуры stat (см. fileTimes и fileOwner).
+// Имена владельцев кэшируются в owners по uid.
+func newFileStat(info os.FileInfo, owners map[uint32]string) fileStat {
+	stat := fileStat{
+		size:  info.Size(),
+		mtime: info.ModTime(),
+		mode:  info.Mode(),
+	}
+	stat.atime, stat.ctime = fileTimes(info)
+
+	if uid, ok := fileOwner(info); ok {
+		name, cached := owners[uid]
+		if !cached {
+			name = fmt.Sprint(uid)
+			if u, err := user.LookupId(name); err == nil {
+				name = u.Username
+			}
+			owners[uid] = name
+		}
+		stat.owner = name
+	}
+	return stat
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createStatFiles создает файлы с заданным размером и временем доступа/изменения.
+func createStatFiles(t *testing.T) map[string]string {
+	t.Helper()
+	dir := t.TempDir()
+	base := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	files := []struct {
+		name  string
+		size  int
+		mtime time.Time
+		atime time.Time
+	}{
+		{name: "big", size: 300, mtime: base, atime: base.Add(2 * time.Hour)},
+		{name: "small", size: 10, mtime: base.Add(2 * time.Hour), atime: base},
+		{name: "medium", size: 100, mtime: base.Add(time.Hour), atime: base.Add(time.Hour)},
+	}
+
+	paths := make(map[string]string, len(files))
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("x", f.size)), 0o644))
+		require.NoError(t, os.Chtimes(path, f.atime, f.mtime))
+		paths[f.name] = path
+	}
+	paths["missing"] = filepath.Join(dir, "missing")
+	return paths
+}
+
+func TestSortByStat(t *testing.T) {
+	identity := func(s string) string { return s }
+	p := createStatFiles(t)
+
+	tests := []struct {
+		name     string
+		input    []string
+		opts     domain.SortOptions
+		expected []string
+	}{
+		{
+			name:     "by size",
+			input:    []string{p["big"], p["small"], p["medium"]},
+			opts:     domain.SortOptions{StatKey: "size"},
+			expected: []string{p["small"], p["medium"], p["big"]},
+		},
+		{
+			name:     "by mtime",
+			input:    []string{p["small"], p["big"], p["medium"]},
+			opts:     domain.SortOptions{StatKey: "mtime"},
+			expected: []string{p["big"], p["medium"], p["small"]},
+		},
+		{
+			name:     "by atime",
+			input:    []string{p["big"], p["medium"], p["small"]},
+			opts:     domain.SortOptions{StatKey: "atime"},
+			expected: []string{p["small"], p["medium"], p["big"]},
+		},
+		{
+			name:     "missing files last by default",
+			input:    []string{p["missing"], p["big"], p["small"]},
+			opts:     domain.SortOptions{StatKey: "size"},
+			expected: []string{p["small"], p["big"], p["missing"]},
+		},
+		{
+			name:     "missing files first",
+			input:    []string{p["big"], p["missing"], p["small"]},
+			opts:     domain.SortOptions{StatKey: "size", StatMissing: domain.PlaceFirst},
+			expected: []string{p["missing"], p["small"], p["big"]},
+		},
+		{
+			name:     "trailing newline stays last",
+			input:    []string{p["big"], p["missing"], p["small"], ""},
+			opts:     domain.SortOptions{StatKey: "size", StatMissing: domain.PlaceFirst},
+			expected: []string{p["missing"], p["small"], p["big"], ""},
+		},
+		{
+			name:     "reverse keeps missing files and empty lines last",
+			input:    []string{p["small"], p["big"], p["missing"], ""},
+			opts:     domain.SortOptions{StatKey: "size", Reverse: true},
+			expected: []string{p["big"], p["small"], p["missing"], ""},
+		},
+		{
+			name:     "path from field",
+			input:    []string{"1 " + p["big"], "2 " + p["small"]},
+			opts:     domain.SortOptions{StatKey: "size", Key: true, Field: 2},
+			expected: []string{"2 " + p["small"], "1 " + p["big"]},
+		},
+		{
+			name:     "equal attributes keep input order",
+			input:    []string{p["medium"], p["big"], p["medium"]},
+			opts:     domain.SortOptions{StatKey: "mode"},
+			expected: []string{p["medium"], p["big"], p["medium"]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := SortByStat(strings.Join(tt.input, "\n"), identity, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, strings.Join(tt.expected, "\n"), result)
+		})
+	}
+
+	// Sort не разворачивает результат повторно
+	input := strings.Join([]string{p["small"], p["big"], p["missing"], ""}, "\n")
+	result, err := Sort(input, domain.SortOptions{StatKey: "size", Reverse: true})
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{p["big"], p["small"], p["missing"], ""}, "\n"), result)
+}
+
+func TestSortByStatMissingError(t *testing.T) {
+	identity := func(s string) string { return s }
+	p := createStatFiles(t)
+
+	input := p["big"] + "\n" + p["missing"]
+	_, err := SortByStat(input, identity, domain.SortOptions{StatKey: "size", StatMissing: domain.PlaceError})
+	require.ErrorIs(t, err, domain.ErrMissingFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Завершающий перевод строки не считается отсутствующим файлом
+	input = p["big"] + "\n" + p["small"] + "\n"
+	result, err := SortByStat(input, identity, domain.SortOptions{StatKey: "size", StatMissing: domain.PlaceError})
+	require.NoError(t, err)
+	require.Equal(t, p["small"]+"\n"+p["big"]+"\n", result)
+}
+
+func TestCompareFileStats(t *testing.T) {
+	base := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	older := fileStat{size: 1, mtime: base, atime: base, ctime: base, mode: 0o600, owner: "alice"}
+	newer := fileStat{size: 2, mtime: base.Add(time.Second), atime: base.Add(time.Second), ctime: base.Add(time.Second), mode: 0o644, owner: "bob"}
+
+	for _, key := range []string{statSize, statMtime, statAtime, statCtime, statMode, statOwner} {
+		t.Run(key, func(t *testing.T) {
+			t.Parallel()
+			require.True(t, compareFileStats(older, newer, key))
+			require.False(t, compareFileStats(newer, older, key))
+		})
+	}
+}
+
+func TestValidateStatOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    domain.SortOptions
+		wantErr error
+	}{
+		{name: "valid", opts: domain.SortOptions{StatKey: "ctime", StatMissing: domain.PlaceError}},
+		{name: "unknown key", opts: domain.SortOptions{StatKey: "inode"}, wantErr: domain.ErrInvalidStatKey},
+		{name: "unknown policy", opts: domain.SortOptions{StatKey: "size", StatMissing: "skip"}, wantErr: domain.ErrInvalidPlace},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateStatOptions(tt.opts)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
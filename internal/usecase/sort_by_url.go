@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"cmp"
+	"net/netip"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// urlKey содержит части URL в порядке их значимости при сортировке.
+type urlKey struct {
+	host   []string
+	port   int // 0, если порт не указан
+	path   []string
+	query  []string
+	scheme string
+	raw    string
+}
+
+// SortByURL выполняет сортировку URL по сайту и структуре (флаг --url-sort).
+// URL сравниваются по хосту (метки справа налево, как в SortByDomain), порту
+// (численно, URL без порта первым), сегментам пути и отсортированным параметрам запроса;
+// схема учитывается последней, поэтому http:// и https:// одного ресурса стоят рядом.
+// Запись без схемы ("example.com/docs") считается URL. Строки, не являющиеся URL,
+// идут последними.
+//
+// Примеры:
+//
+//	"https://www.example.com/b\nhttp://example.com/a" → "http://example.com/a\nhttps://www.example.com/b"
+//	"https://a.com/x?b=2&a=1\nhttps://a.com/x?a=1" → "https://a.com/x?a=1\nhttps://a.com/x?b=2&a=1"
+func SortByURL(s string, modify func(string) string, opts domain.SortOptions) string {
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareURLStrings(modify(rows[i]), modify(rows[j]))
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareURLStrings сравнивает две строки по правилам сортировки URL.
+//
+// Примеры порядка:
+//
+//	example.com < example.com/a < example.com/a/b < example.com/b < api.example.com < abc def
+func compareURLStrings(iStr, jStr string) bool {
+	iURL, iOk := parseURLKey(iStr)
+	jURL, jOk := parseURLKey(jStr)
+
+	switch {
+	case iOk && jOk:
+		return cmp.Or(
+			slices.Compare(iURL.host, jURL.host),
+			cmp.Compare(iURL.port, jURL.port),
+			slices.Compare(iURL.path, jURL.path),
+			slices.Compare(iURL.query, jURL.query),
+			strings.Compare(iURL.scheme, jURL.scheme),
+			strings.Compare(iURL.raw, jURL.raw),
+		) < 0
+	case iOk && !jOk:
+		return true
+	case !iOk && jOk:
+		return false
+	default:
+		return iStr < jStr
+	}
+}
+
+// parseURLKey разбирает URL на части для сравнения. Хост-IP-адрес не разворачивается.
+// Пустые сегменты пути отбрасываются, поэтому "/a/" и "/a" эквивалентны по пути.
+func parseURLKey(s string) (urlKey, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, " \t") {
+		return urlKey{}, false
+	}
+
+	raw := s
+	if !strings.Contains(s, "://") {
+		s = "//" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Hostname() == "" {
+		return urlKey{}, false
+	}
+
+	key := urlKey{
+		scheme: strings.ToLower(u.Scheme),
+		raw:    raw,
+	}
+	// url.Parse допускает только цифры в порте; пустой порт дает 0
+	key.port, _ = strconv.Atoi(u.Port())
+
+	host := u.Hostname()
+	if _, err := netip.ParseAddr(host); err == nil {
+		key.host = []string{host}
+	} else if labels, ok := domainLabels(host); ok {
+		key.host = labels
+	} else {
+		return urlKey{}, false
+	}
+
+	for _, segment := range strings.Split(u.EscapedPath(), "/") {
+		if segment != "" {
+			key.path = append(key.path, segment)
+		}
+	}
+
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair != "" {
+			key.query = append(key.query, pair)
+		}
+	}
+	slices.Sort(key.query)
+
+	return key, true
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByURL(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "group by host ignoring scheme",
+			input:    "https://www.example.com/b\nhttps://example.org/\nhttp://example.com/a",
+			expected: "http://example.com/a\nhttps://www.example.com/b\nhttps://example.org/",
+		},
+		{
+			name:     "path segments",
+			input:    "https://a.com/b\nhttps://a.com/a/z\nhttps://a.com/a\nhttps://a.com/",
+			expected: "https://a.com/\nhttps://a.com/a\nhttps://a.com/a/z\nhttps://a.com/b",
+		},
+		{
+			name:     "path segments are not plain bytes",
+			input:    "https://a.com/a-b\nhttps://a.com/a/b",
+			expected: "https://a.com/a/b\nhttps://a.com/a-b",
+		},
+		{
+			name:     "sorted query parameters",
+			input:    "https://a.com/x?b=2&a=1\nhttps://a.com/x?a=1\nhttps://a.com/x?a=1&b=1",
+			expected: "https://a.com/x?a=1\nhttps://a.com/x?a=1&b=1\nhttps://a.com/x?b=2&a=1",
+		},
+		{
+			name:     "scheme breaks ties",
+			input:    "https://a.com/x\nhttp://a.com/x",
+			expected: "http://a.com/x\nhttps://a.com/x",
+		},
+		{
+			name:     "urls without scheme",
+			input:    "www.example.com/docs\nexample.com/about",
+			expected: "example.com/about\nwww.example.com/docs",
+		},
+		{
+			name:     "ports",
+			input:    "http://a.com:8080/\nhttp://a.com/\nhttp://a.com:443/",
+			expected: "http://a.com/\nhttp://a.com:443/\nhttp://a.com:8080/",
+		},
+		{
+			name:     "ports numerically",
+			input:    "http://a.com:10000/\nhttp://a.com:9000/",
+			expected: "http://a.com:9000/\nhttp://a.com:10000/",
+		},
+		{
+			name:     "single label hosts except localhost last",
+			input:    "word\nhttp://localhost:8080/\nhttps://a.com",
+			expected: "https://a.com\nhttp://localhost:8080/\nword",
+		},
+		{
+			name:     "invalid urls last",
+			input:    "not a url\nhttps://a.com\nmailto:",
+			expected: "https://a.com\nmailto:\nnot a url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByURL(tt.input, identity, domain.SortOptions{})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareURLStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "host before path",
+			a:        "https://b.example.com/a",
+			b:        "https://example.com/z",
+			expected: false,
+		},
+		{
+			name:     "ip host",
+			a:        "http://10.0.0.1/",
+			b:        "http://example.com/",
+			expected: true,
+		},
+		{
+			name:     "url before invalid",
+			a:        "https://a.com",
+			b:        "abc def",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareURLStrings(tt.a, tt.b)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
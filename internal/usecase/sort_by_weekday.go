@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// weekdayNames определяет названия дней недели по локалям и их порядок:
+// понедельник (1) → воскресенье (7), как в ISO 8601. Для русского перечислены
+// также формы винительного падежа ("в среду", "в пятницу").
+var weekdayNames = map[string]map[string]int{
+	"en": {
+		"mon": 1, "monday": 1,
+		"tue": 2, "tues": 2, "tuesday": 2,
+		"wed": 3, "weds": 3, "wednesday": 3,
+		"thu": 4, "thur": 4, "thurs": 4, "thursday": 4,
+		"fri": 5, "friday": 5,
+		"sat": 6, "saturday": 6,
+		"sun": 7, "sunday": 7,
+	},
+	"ru": {
+		"пн": 1, "пон": 1, "понедельник": 1,
+		"вт": 2, "вто": 2, "вторник": 2,
+		"ср": 3, "сре": 3, "среда": 3, "среду": 3,
+		"чт": 4, "чет": 4, "четверг": 4,
+		"пт": 5, "пят": 5, "пятница": 5, "пятницу": 5,
+		"сб": 6, "суб": 6, "суббота": 6, "субботу": 6,
+		"вс": 7, "вос": 7, "воскресенье": 7,
+	},
+}
+
+// SortByWeekday выполняет сортировку по дням недели (флаг --weekday-sort).
+// Работает аналогично SortByMonth: распознает название дня в начале строки по таблице
+// локали --month-locale (или LC_TIME) и сортирует от понедельника к воскресенью.
+// Строки, не начинающиеся с дня недели, сортируются лексикографически и идут первыми.
+//
+// Примеры:
+//
+//	"Wed\nMon\nSunday" → "Mon\nWed\nSunday"
+//	"пятница\nвторник" с локалью ru → "вторник\nпятница"
+//	"abc\nFri\nMon" → "abc\nMon\nFri" (не-дни первыми)
+func SortByWeekday(s string, modify func(string) string, opts domain.SortOptions) string {
+	weekdays := calendarNames(weekdayNames, opts.MonthLocale)
+	rows := strings.Split(s, "\n")
+	sort.SliceStable(rows, func(i, j int) bool {
+		return compareWeekdayStrings(modify(rows[i]), modify(rows[j]), weekdays)
+	})
+	return strings.Join(rows, "\n")
+}
+
+// compareWeekdayStrings сравнивает две строки по правилам сортировки дней недели.
+// Примеры порядка:
+//
+//	"abc" < "Mon" < "Tuesday" < ... < "Sun"
+func compareWeekdayStrings(iStr, jStr string, weekdays map[string]int) bool {
+	return compareCalendarStrings(iStr, jStr, weekdays)
+}
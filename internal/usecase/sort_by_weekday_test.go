@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByWeekday(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		locale   string
+		expected string
+	}{
+		{
+			name:     "abbreviations",
+			input:    "Sun\nWed\nMon\nFri",
+			expected: "Mon\nWed\nFri\nSun",
+		},
+		{
+			name:     "full names and variants",
+			input:    "Thursday\nTues\nsaturday",
+			expected: "Tues\nThursday\nsaturday",
+		},
+		{
+			name:     "weekday at start of key",
+			input:    "Fri 18:00 standup\nMon 09:00 planning",
+			expected: "Mon 09:00 planning\nFri 18:00 standup",
+		},
+		{
+			name:     "non-weekdays first",
+			input:    "Tue\nabc\nMon",
+			expected: "abc\nMon\nTue",
+		},
+		{
+			name:     "russian names",
+			input:    "вс\nпятницу\nпонедельник\nСреда",
+			locale:   "ru_RU.UTF-8",
+			expected: "понедельник\nСреда\nпятницу\nвс",
+		},
+		{
+			name:     "russian names ignored in english locale",
+			input:    "Mon\nвторник",
+			locale:   "en_US.UTF-8",
+			expected: "вторник\nMon",
+		},
+		{
+			name:     "empty input",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortByWeekday(tt.input, identity, domain.SortOptions{MonthLocale: tt.locale})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCompareWeekdayStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "Mon before Tue",
+			a:        "Mon",
+			b:        "Tue",
+			expected: true,
+		},
+		{
+			name:     "Sunday is last",
+			a:        "Sunday",
+			b:        "Sat",
+			expected: false,
+		},
+		{
+			name:     "non-weekday before weekday",
+			a:        "abc",
+			b:        "Mon",
+			expected: true,
+		},
+		{
+			name:     "case insensitive",
+			a:        "WED",
+			b:        "thu",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := compareWeekdayStrings(tt.a, tt.b, weekdayNames["en"])
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
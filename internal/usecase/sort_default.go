@@ -3,22 +3,29 @@ package usecase
 import (
 	"sort"
 	"strings"
+	"unix_sort_lite/internal/domain"
 )
 
 // SortDefault выполняет лексикографическую сортировку (по умолчанию в Unix sort).
-// Сортирует строки по ASCII/Unicode значениям символов, символ за символом.
+// В локалях C и POSIX сортирует строки по ASCII/Unicode значениям символов, символ за символом.
+// В остальных локалях (--locale или LC_ALL/LC_COLLATE/LANG) применяет правила сравнения
+// языка: буквы с диакритикой стоят рядом с базовыми, "ё" — после "е", регистр учитывается
+// в последнюю очередь (порядок регистра задает --case-first).
 // Поддерживает модификацию строк перед сравнением (например, для флага -b).
 //
 // Примеры:
 //
 //	"c\nb\na" → "a\nb\nc"
-//	"Zebra\napple\nBanana" → "Banana\nZebra\napple" (заглавные первыми)
+//	"Zebra\napple\nBanana" → "Banana\nZebra\napple" (заглавные первыми в локали C)
+//	"Zebra\napple\nBanana" с локалью en_US → "apple\nBanana\nZebra"
+//	"Яблоко\nЁлка" с локалью ru_RU → "Ёлка\nЯблоко"
 //	"10\n2\n1" → "1\n10\n2" (лексикографически, не числово)
 //	"apple  \nbanana \ncherry" с -b → сравнение без trailing пробелов
-func SortDefault(s string, modify func(string) string) string {
+func SortDefault(s string, modify func(string) string, opts domain.SortOptions) string {
+	coll, _ := newCollation(opts) // локаль проверяется в Sort
 	rows := strings.Split(s, "\n")
 	sort.SliceStable(rows, func(i, j int) bool {
-		return modify(rows[i]) < modify(rows[j])
+		return coll.less(modify(rows[i]), modify(rows[j]))
 	})
 	return strings.Join(rows, "\n")
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"testing"
+	"unix_sort_lite/internal/domain"
 
 	"github.com/stretchr/testify/require"
 )
@@ -109,7 +110,7 @@ func TestSortDefault(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := SortDefault(tt.input, identity)
+			result := SortDefault(tt.input, identity, domain.SortOptions{})
 			require.Equal(t, tt.expected, result)
 		})
 	}
@@ -145,7 +146,7 @@ func TestSortDefaultWithModify(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := SortDefault(tt.input, trimBlanks)
+			result := SortDefault(tt.input, trimBlanks, domain.SortOptions{})
 			require.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// sortWithHeader сортирует ввод, закрепляя первые opts.Header строк (флаг --header):
+// заголовок выводится без изменений, сортируются только строки после него.
+// С --csv заголовок считается в записях CSV. Список --order-by разрешается по именам
+// колонок последней строки заголовка в ключи opts.Keys.
+//
+// Примеры:
+//
+//	"host latency\nb 5\na 30" с --header=1 → "host latency\na 30\nb 5"
+//	то же с --order-by "latency:n DESC" → "host latency\na 30\nb 5"
+func sortWithHeader(input string, opts domain.SortOptions) (string, error) {
+	header, body, names, err := splitHeader(input, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.OrderBy != "" {
+		if opts.Keys, err = parseOrderBy(opts.OrderBy, names); err != nil {
+			return "", err
+		}
+	}
+	opts.Header, opts.OrderBy = 0, ""
+
+	result, err := Sort(body, opts)
+	if err != nil {
+		return "", err
+	}
+	if len(header) == len(input) {
+		return header, nil
+	}
+	return header + "\n" + result, nil
+}
+
+// splitHeader отделяет заголовок из opts.Header строк (записей CSV с --csv)
+// и возвращает имена колонок его последней строки. Если строк меньше, заголовком
+// считается весь ввод, а тело пусто.
+func splitHeader(input string, opts domain.SortOptions) (header, body string, names []string, err error) {
+	records, err := splitRecords(input, opts)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	n := min(opts.Header, len(records))
+	raws := make([]string, n)
+	for i, rec := range records[:n] {
+		raws[i] = rec.raw
+	}
+	header = strings.Join(raws, "\n")
+	if n < len(records) {
+		body = input[len(header)+1:]
+	}
+
+	for _, name := range records[n-1].fields {
+		names = append(names, strings.TrimSpace(name))
+	}
+	return header, body, names, nil
+}
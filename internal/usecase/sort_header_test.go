@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortWithHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "header stays in place",
+			input:    "name\ncherry\napple",
+			opts:     domain.SortOptions{Header: 1},
+			expected: "name\napple\ncherry",
+		},
+		{
+			name:     "several header lines",
+			input:    "# report\nname\nb\na",
+			opts:     domain.SortOptions{Header: 2},
+			expected: "# report\nname\na\nb",
+		},
+		{
+			name:     "header longer than input",
+			input:    "b\na",
+			opts:     domain.SortOptions{Header: 5},
+			expected: "b\na",
+		},
+		{
+			name:     "reverse applies to body only",
+			input:    "n\n1\n3\n2",
+			opts:     domain.SortOptions{Header: 1, Numeric: true, Reverse: true},
+			expected: "n\n3\n2\n1",
+		},
+		{
+			name:     "order by named columns",
+			input:    "host latency\na 30\nb 5\nc\nd 100",
+			opts:     domain.SortOptions{Header: 1, OrderBy: "latency:n DESC NULLS LAST, host"},
+			expected: "host latency\nd 100\na 30\nb 5\nc",
+		},
+		{
+			name:     "csv header record with embedded newline",
+			input:    "\"host\nname\",latency\nb,5\na,30",
+			opts:     domain.SortOptions{Header: 1, CSV: true, OrderBy: "latency:n"},
+			expected: "\"host\nname\",latency\nb,5\na,30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Sort(tt.input, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSortWithHeaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    domain.SortOptions
+		wantErr error
+	}{
+		{name: "order by without header", opts: domain.SortOptions{OrderBy: "host"}, wantErr: domain.ErrOrderByHeader},
+		{name: "negative header", opts: domain.SortOptions{Header: -1}, wantErr: domain.ErrInvalidHeader},
+		{name: "unknown column", opts: domain.SortOptions{Header: 1, OrderBy: "region"}, wantErr: domain.ErrUnknownColumn},
+		{name: "order by with -k", opts: domain.SortOptions{Header: 1, OrderBy: "host", Key: true, Field: 1}, wantErr: domain.ErrConflictOpts},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Sort("host latency\na 1", tt.opts)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// Виды узлов документа JSON
+const (
+	nodeNull = iota
+	nodeNumber
+	nodeString
+	nodeBool
+	nodeObject
+	nodeArray
+)
+
+// jsonNode — узел документа JSON с сохранением порядка ключей объектов
+// и исходной записи узла.
+type jsonNode struct {
+	kind   int
+	raw    string      // запись числа или логического значения, значение строки
+	text   string      // исходная запись узла
+	keys   []string    // ключи объекта
+	names  []string    // исходные записи ключей объекта вместе с двоеточием: `"name": `
+	values []*jsonNode // значения объекта или элементы массива
+	gaps   []string    // исходные промежутки после "{" или "[", между элементами и перед "}" или "]"
+}
+
+// wildcard — сегмент пути, выбирающий все элементы массива или значения объекта.
+const wildcard = "*"
+
+// parseDocPath разбирает путь JSONPath к массивам: "$.dependencies[*]", "$.a[0].b",
+// "$.groups.*.members" или "$". Завершающий "[*]" можно не указывать: сортируется
+// массив, на который указывает путь.
+func parseDocPath(path string) ([]string, error) {
+	invalid := fmt.Errorf("%w: %q", domain.ErrInvalidJSONPath, path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid
+	}
+
+	var tokens []string
+	for rest := path[1:]; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, "[*]"):
+			tokens = append(tokens, wildcard)
+			rest = rest[3:]
+		case strings.HasPrefix(rest, `["`):
+			end := strings.Index(rest, `"]`)
+			if end < 0 {
+				return nil, invalid
+			}
+			tokens = append(tokens, rest[2:end])
+			rest = rest[end+2:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, invalid
+			}
+			if _, err := strconv.Atoi(rest[1:end]); err != nil {
+				return nil, invalid
+			}
+			tokens = append(tokens, rest[1:end])
+			rest = rest[end+1:]
+		case rest[0] == '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			if end == 0 {
+				return nil, invalid
+			}
+			tokens = append(tokens, rest[1:end+1])
+			rest = rest[end+1:]
+		default:
+			return nil, invalid
+		}
+	}
+
+	// Завершающий [*] обозначает сам массив
+	if n := len(tokens); n > 0 && tokens[n-1] == wildcard && !strings.HasSuffix(path, ".*") {
+		tokens = tokens[:n-1]
+	}
+	return tokens, nil
+}
+
+// validateJSONDocument проверяет пути --json-array и ключ --json-array-key.
+func validateJSONDocument(opts domain.SortOptions) error {
+	for _, path := range opts.JSONArrays {
+		if _, err := parseDocPath(path); err != nil {
+			return err
+		}
+	}
+	if opts.JSONArrayKey == "" {
+		return nil
+	}
+	path, _ := splitKeyType(opts.JSONArrayKey)
+	_, err := parseJSONPath(path)
+	return err
+}
+
+// SortJSONDocument сортирует массивы внутри документа JSON (флаг --json).
+// Массивы выбираются путями --json-array ("$.dependencies[*]", "$.groups.*.members"),
+// элементы сравниваются по ключу --json-array-key (".name", "/version:n"; по умолчанию
+// элемент целиком) по правилам --jsonl: значения без типа — по типу JSON, null и
+// отсутствующие — согласно --json-null, в том числе с -r, который меняет только порядок
+// значений. С -u элементы с равным ключом удаляются. --sort-object-keys сортирует ключи
+// всех объектов.
+//
+// Разметка документа сохраняется: переставляются только элементы массивов и ключи
+// объектов, а отступы, переносы строк и промежутки между элементами остаются на своих
+// местах, поэтому документ без изменений выводится байт в байт. Записи чисел и строк
+// сохраняются: 1.50 и 1e3 не превращаются в 1.5 и 1000. Повторные ключи объектов
+// сохраняются, --sort-object-keys оставляет их в исходном порядке.
+//
+// Примеры:
+//
+//	`{"deps":[{"name":"b"},{"name":"a"}]}` с --json-array '$.deps[*]' --json-array-key .name
+//	→ `{"deps":[{"name":"a"},{"name":"b"}]}`
+//	`{"b":1,"a":{"d":2,"c":3}}` с --sort-object-keys → `{"a":{"c":3,"d":2},"b":1}`
+func SortJSONDocument(s string, modify func(string) string, opts domain.SortOptions) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	root, err := decodeNode(decoder, s)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", domain.ErrInvalidJSON, err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("%w: unexpected data after document", domain.ErrInvalidJSON)
+	}
+
+	if opts.SortObjectKeys {
+		sortObjectKeys(root)
+	}
+
+	keySpec, keyType := splitKeyType(opts.JSONArrayKey)
+	if keySpec == "" {
+		keySpec = "."
+	}
+	keyPath, _ := parseJSONPath(keySpec) // ключ проверяется в Sort
+	less := keyComparator(keyTypeOptions(opts, keyType))
+	nullsFirst := opts.JSONNull == domain.PlaceFirst
+
+	for _, path := range opts.JSONArrays {
+		tokens, _ := parseDocPath(path) // пути проверяются в Sort
+		for _, array := range selectNodes(root, tokens) {
+			if array.kind != nodeArray {
+				continue
+			}
+			sortArray(array, func(elem *jsonNode) jsonValue {
+				value := lookupNode(elem, keyPath)
+				value.text = modify(value.text)
+				return value
+			}, func(i, j jsonValue) int {
+				return compareJSONValues(i, j, keyType != "", less, nullsFirst, opts.Reverse)
+			}, opts)
+		}
+	}
+
+	// Пробелы вокруг документа выводятся как во вводе
+	end := int(decoder.InputOffset())
+	var b strings.Builder
+	b.WriteString(s[:end-len(root.text)])
+	root.write(&b, true)
+	b.WriteString(s[end:])
+	return b.String(), nil
+}
+
+// sortArray сортирует элементы массива по ключу compare (-r учитывается в нем) и применяет -u.
+func sortArray(array *jsonNode, key func(*jsonNode) jsonValue, compare func(i, j jsonValue) int, opts domain.SortOptions) {
+	type element struct {
+		node *jsonNode
+		key  jsonValue
+	}
+	elements := make([]element, len(array.values))
+	for i, node := range array.values {
+		elements[i] = element{node: node, key: key(node)}
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		return compare(elements[i].key, elements[j].key) < 0
+	})
+
+	array.values = array.values[:0]
+	for i, elem := range elements {
+		if opts.Unique && i > 0 && compare(elements[i-1].key, elem.key) == 0 {
+			continue
+		}
+		array.values = append(array.values, elem.node)
+	}
+}
+
+// decodeNode читает очередное значение JSON из decoder вместе с его исходной записью в s.
+func decodeNode(decoder *json.Decoder, s string) (*jsonNode, error) {
+	start := skipSeparators(s, int(decoder.InputOffset()))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	var node *jsonNode
+	switch token := token.(type) {
+	case json.Delim:
+		node = &jsonNode{kind: nodeArray}
+		if token == '{' {
+			node.kind = nodeObject
+		}
+		last := int(decoder.InputOffset())
+		for decoder.More() {
+			memberStart := skipSeparators(s, last)
+			node.gaps = append(node.gaps, s[last:memberStart])
+			if node.kind == nodeObject {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key.(string))
+			}
+			value, err := decodeNode(decoder, s)
+			if err != nil {
+				return nil, err
+			}
+			node.values = append(node.values, value)
+			last = int(decoder.InputOffset())
+			if node.kind == nodeObject {
+				node.names = append(node.names, s[memberStart:last-len(value.text)])
+			}
+		}
+		if _, err := decoder.Token(); err != nil { // закрывающая скобка
+			return nil, err
+		}
+		if len(node.values) > 0 {
+			node.gaps = append(node.gaps, s[last:decoder.InputOffset()-1])
+		}
+	case json.Number:
+		node = &jsonNode{kind: nodeNumber, raw: token.String()}
+	case string:
+		node = &jsonNode{kind: nodeString, raw: token}
+	case bool:
+		node = &jsonNode{kind: nodeBool, raw: strconv.FormatBool(token)}
+	default:
+		node = &jsonNode{kind: nodeNull}
+	}
+	node.text = s[start:decoder.InputOffset()]
+	return node, nil
+}
+
+// skipSeparators пропускает пробелы и разделители "," и ":" начиная с позиции i.
+func skipSeparators(s string, i int) int {
+	for i < len(s) && strings.IndexByte(" \t\r\n,:", s[i]) >= 0 {
+		i++
+	}
+	return i
+}
+
+// selectNodes возвращает узлы, на которые указывает путь; "*" выбирает все дочерние узлы.
+func selectNodes(root *jsonNode, tokens []string) []*jsonNode {
+	nodes := []*jsonNode{root}
+	for _, token := range tokens {
+		var next []*jsonNode
+		for _, node := range nodes {
+			switch {
+			case token == wildcard:
+				next = append(next, node.values...)
+			case node.kind == nodeObject:
+				if i := node.member(token); i >= 0 {
+					next = append(next, node.values[i])
+				}
+			case node.kind == nodeArray:
+				if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(node.values) {
+					next = append(next, node.values[i])
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// member возвращает индекс ключа объекта или -1; при повторе ключа берется последний, как в encoding/json.
+func (n *jsonNode) member(key string) int {
+	for i := len(n.keys) - 1; i >= 0; i-- {
+		if n.keys[i] == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// lookupNode извлекает значение ключа элемента по пути в формате --jsonl.
+func lookupNode(node *jsonNode, path []string) jsonValue {
+	for _, token := range path {
+		i := -1
+		switch node.kind {
+		case nodeObject:
+			i = node.member(token)
+		case nodeArray:
+			if n, err := strconv.Atoi(token); err == nil && n >= 0 && n < len(node.values) {
+				i = n
+			}
+		}
+		if i < 0 {
+			return jsonValue{}
+		}
+		node = node.values[i]
+	}
+
+	switch node.kind {
+	case nodeNull:
+		return jsonValue{}
+	case nodeNumber:
+		num, _ := strconv.ParseFloat(node.raw, 64)
+		return jsonValue{kind: jsonNumber, num: num, text: node.raw}
+	case nodeString:
+		return jsonValue{kind: jsonString, text: node.raw}
+	case nodeBool:
+		return jsonValue{kind: jsonBool, text: node.raw}
+	default:
+		var b strings.Builder
+		node.write(&b, false)
+		return jsonValue{kind: jsonComposite, text: b.String()}
+	}
+}
+
+// sortObjectKeys рекурсивно сортирует ключи объектов побайтово.
+func sortObjectKeys(node *jsonNode) {
+	for _, value := range node.values {
+		sortObjectKeys(value)
+	}
+	if node.kind != nodeObject {
+		return
+	}
+	sort.Stable(objectMembers{node})
+}
+
+// objectMembers упорядочивает пары ключ-значение объекта по ключу.
+type objectMembers struct{ *jsonNode }
+
+func (m objectMembers) Len() int           { return len(m.keys) }
+func (m objectMembers) Less(i, j int) bool { return m.keys[i] < m.keys[j] }
+func (m objectMembers) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.values[i], m.values[j] = m.values[j], m.values[i]
+	m.names[i], m.names[j] = m.names[j], m.names[i]
+}
+
+// write записывает узел в b. С layout сохраняется исходная разметка: значения и пустые
+// объекты и массивы выводятся как во вводе, а элементы разделяются исходными промежутками
+// по их позициям, так что перестановка элементов не меняет отступов. Без layout
+// запись компактная.
+func (n *jsonNode) write(b *strings.Builder, layout bool) {
+	if layout && len(n.values) == 0 {
+		b.WriteString(n.text)
+		return
+	}
+
+	switch n.kind {
+	case nodeNull:
+		b.WriteString("null")
+	case nodeNumber, nodeBool:
+		b.WriteString(n.raw)
+	case nodeString:
+		writeJSONString(b, n.raw)
+	default:
+		open, closing := "[", "]"
+		if n.kind == nodeObject {
+			open, closing = "{", "}"
+		}
+		b.WriteString(open)
+		for i, value := range n.values {
+			if layout {
+				b.WriteString(n.gaps[i])
+			} else if i > 0 {
+				b.WriteByte(',')
+			}
+			if n.kind == nodeObject {
+				if layout {
+					b.WriteString(n.names[i])
+				} else {
+					writeJSONString(b, n.keys[i])
+					b.WriteByte(':')
+				}
+			}
+			value.write(b, layout)
+		}
+		if layout {
+			b.WriteString(n.gaps[len(n.gaps)-1])
+		}
+		b.WriteString(closing)
+	}
+}
+
+// writeJSONString записывает строку JSON без экранирования <, > и &.
+func writeJSONString(b *strings.Builder, s string) {
+	var out strings.Builder
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	b.WriteString(strings.TrimSuffix(out.String(), "\n"))
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDocPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{name: "array with wildcard", path: "$.dependencies[*]", expected: []string{"dependencies"}},
+		{name: "array without wildcard", path: "$.dependencies", expected: []string{"dependencies"}},
+		{name: "nested arrays", path: "$.groups[*].members[*]", expected: []string{"groups", "*", "members"}},
+		{name: "object values", path: "$.groups.*", expected: []string{"groups", "*"}},
+		{name: "index and bracket name", path: `$.a[0]["b.c"]`, expected: []string{"a", "0", "b.c"}},
+		{name: "root", path: "$", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tokens, err := parseDocPath(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, tokens)
+		})
+	}
+
+	for _, path := range []string{".deps", "$..deps", "$.deps[x]", "$.deps[0", `$["a`} {
+		t.Run("invalid "+path, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseDocPath(path)
+			require.ErrorIs(t, err, domain.ErrInvalidJSONPath)
+		})
+	}
+}
+
+func TestSortJSONDocument(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "array of objects by key",
+			input:    `{"deps":[{"name":"b","v":1.50},{"name":"a","v":1e3}]}`,
+			opts:     domain.SortOptions{JSONArrays: []string{"$.deps[*]"}, JSONArrayKey: ".name"},
+			expected: `{"deps":[{"name":"a","v":1e3},{"name":"b","v":1.50}]}`,
+		},
+		{
+			name:     "whole elements by json type",
+			input:    `{"allow":["b",10,"a",9,true]}`,
+			opts:     domain.SortOptions{JSONArrays: []string{"$.allow"}},
+			expected: `{"allow":[9,10,"a","b",true]}`,
+		},
+		{
+			name:     "typed key",
+			input:    `[{"v":"10"},{"v":"9"}]`,
+			opts:     domain.SortOptions{JSONArrays: []string{"$"}, JSONArrayKey: "/v:n"},
+			expected: `[{"v":"9"},{"v":"10"}]`,
+		},
+		{
+			name:     "wildcard over nested arrays",
+			input:    `{"g":[{"m":["y","x"]},{"m":["b","a"]}]}`,
+			opts:     domain.SortOptions{JSONArrays: []string{"$.g[*].m[*]"}},
+			expected: `{"g":[{"m":["x","y"]},{"m":["a","b"]}]}`,
+		},
+		{
+			name:     "missing key stays last with reverse and unique",
+			input:    `[{"n":"a"},{},{"n":"b"},{"n":"a","x":1}]`,
+			opts:     domain.SortOptions{JSONArrays: []string{"$"}, JSONArrayKey: ".n", Reverse: true, Unique: true},
+			expected: `[{"n":"b"},{"n":"a"},{}]`,
+		},
+		{
+			name:     "object keys recursively",
+			input:    `{"b":1,"a":{"d":[{"z":1,"y":2}],"c":3}}`,
+			opts:     domain.SortOptions{SortObjectKeys: true},
+			expected: `{"a":{"c":3,"d":[{"y":2,"z":1}]},"b":1}`,
+		},
+		{
+			name:     "indentation and escapes preserved",
+			input:    "{\n  \"b\": [\"x\\u00e9\", \"<y>\"],\n  \"a\": {},\n  \"e\": []\n}\n",
+			opts:     domain.SortOptions{JSONArrays: []string{"$.b"}, SortObjectKeys: true},
+			expected: "{\n  \"a\": {},\n  \"b\": [\"<y>\", \"x\\u00e9\"],\n  \"e\": []\n}\n",
+		},
+		{
+			name: "untouched subtrees keep layout",
+			input: "{\n\t\"deps\": [\n\t\t{\"name\": \"b\", \"range\": [1, 2]},\n\t\t{\n\t\t\t\"name\": \"a\"\n\t\t}\n\t],\n" +
+				"\t\"meta\": {\"z\":1,\"y\":2}\n}",
+			opts: domain.SortOptions{JSONArrays: []string{"$.deps"}, JSONArrayKey: ".name"},
+			expected: "{\n\t\"deps\": [\n\t\t{\n\t\t\t\"name\": \"a\"\n\t\t},\n\t\t{\"name\": \"b\", \"range\": [1, 2]}\n\t],\n" +
+				"\t\"meta\": {\"z\":1,\"y\":2}\n}",
+		},
+		{
+			name:     "unique keeps separators",
+			input:    "[\n  3,\n  1,\n  3\n]",
+			opts:     domain.SortOptions{JSONArrays: []string{"$"}, Unique: true},
+			expected: "[\n  1,\n  3\n]",
+		},
+		{
+			name:     "path to missing array ignored",
+			input:    `{"a":1}`,
+			opts:     domain.SortOptions{JSONArrays: []string{"$.deps", "$.a"}},
+			expected: `{"a":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.opts.JSON = true
+			result, err := SortJSONDocument(tt.input, identity, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("nothing to sort round-trips byte for byte", func(t *testing.T) {
+		t.Parallel()
+		input := "\n{\n    \"name\" : \"x\\/y\",\n    \"list\": [ 1.50, 1e3 ,\"a\"],\r\n    \"empty\": { },\n" +
+			"    \"nested\": {\"b\": [{\"k\": null}], \"a\": true}\n}\n\n"
+		result, err := SortJSONDocument(input, identity, domain.SortOptions{JSON: true, JSONArrays: []string{"$.list"}})
+		require.NoError(t, err)
+		require.Equal(t, input, result)
+	})
+
+	for _, input := range []string{`{"a":`, `{"a":1} {"b":2}`, ``} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			t.Parallel()
+			_, err := SortJSONDocument(input, identity, domain.SortOptions{JSON: true})
+			require.ErrorIs(t, err, domain.ErrInvalidJSON)
+		})
+	}
+}
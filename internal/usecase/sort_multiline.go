@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// newRecordStart компилирует выражение --record-start; пустое выражение дает nil.
+func newRecordStart(opts domain.SortOptions) (*regexp.Regexp, error) {
+	if opts.RecordStart == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(opts.RecordStart)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidRecordStart, err)
+	}
+	return re, nil
+}
+
+// SortMultiline сортирует многострочные записи целиком: абзацы, разделенные пустыми
+// строками (флаг --paragraph), или записи, начинающиеся со строки, совпавшей с --record-start,
+// вместе со следующими несовпавшими строками (stack trace, запись LDIF, письмо mbox).
+// Строки до первой записи и пустые строки в начале и конце ввода остаются на месте;
+// абзацы разделяются так же, как первые два абзаца во вводе.
+//
+// Ключ берется из строки --key-line записи (по умолчанию первой): строка целиком
+// с типом из флагов (-n, --date-sort, ...), поле -k N или ключи -k N:type.
+// Записи без этой строки идут последними.
+//
+// Примеры:
+//
+//	"[b]\nx=1\n\n[a]\ny=2" с --paragraph → "[a]\ny=2\n\n[b]\nx=1"
+//	"E2 boom\n  at f\nE1 fail" с --record-start '^E' → "E1 fail\nE2 boom\n  at f"
+func SortMultiline(s string, modify func(string) string, opts domain.SortOptions) string {
+	lines := strings.Split(s, "\n")
+
+	var (
+		prefix, suffix []string
+		groups         [][]string
+		separator      = "\n"
+	)
+	if opts.Paragraph {
+		prefix, groups, suffix, separator = splitParagraphs(lines)
+	} else {
+		// Пустые строки в конце ввода не присоединяются к последней записи
+		last := len(lines)
+		for last > 0 && lines[last-1] == "" {
+			last--
+		}
+		start, _ := newRecordStart(opts) // выражение проверяется в Sort
+		prefix, groups = splitRecordStart(lines[:last], start)
+		suffix = lines[last:]
+	}
+
+	keyLine := max(opts.KeyLine, 1)
+	records := make([]record, len(groups))
+	for i, group := range groups {
+		records[i].raw = strings.Join(group, "\n")
+		if keyLine > len(group) {
+			continue
+		}
+		if opts.Key || len(opts.Keys) > 0 {
+			records[i].fields = lineFields(group[keyLine-1], opts)
+		} else {
+			records[i].fields = []string{group[keyLine-1]}
+		}
+	}
+
+	// Ключи записи — поля строки ключа; без -k ключ — строка целиком
+	keyOpts := opts
+	switch {
+	case opts.Key:
+		keyOpts.Keys = []domain.SortKey{{Field: opts.Field}}
+	case len(opts.Keys) == 0:
+		keyOpts.Keys = []domain.SortKey{{Field: 1}}
+	}
+	keyOpts.Key, keyOpts.Field = false, 0
+
+	sortRecords(records, modify, keyOpts)
+	records = orderRecords(records, modify, keyOpts)
+
+	raws := make([]string, len(records))
+	for i, rec := range records {
+		raws[i] = rec.raw
+	}
+	var parts []string
+	if len(prefix) > 0 {
+		parts = append(parts, strings.Join(prefix, "\n"))
+	}
+	if len(raws) > 0 {
+		parts = append(parts, strings.Join(raws, separator))
+	}
+	if len(suffix) > 0 {
+		parts = append(parts, strings.Join(suffix, "\n"))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// splitParagraphs разбивает строки на абзацы по пустым строкам (в том числе из пробелов).
+// Возвращает пустые строки до первого и после последнего абзаца и разделитель абзацев —
+// первый промежуток между абзацами во вводе.
+func splitParagraphs(lines []string) (prefix []string, paragraphs [][]string, suffix []string, separator string) {
+	blank := func(line string) bool { return strings.TrimSpace(line) == "" }
+
+	first, last := 0, len(lines)
+	for first < last && blank(lines[first]) {
+		first++
+	}
+	for last > first && blank(lines[last-1]) {
+		last--
+	}
+	prefix, suffix = lines[:first], lines[last:]
+
+	separator = "\n\n"
+	var gap []string
+	for i := first; i < last; {
+		if blank(lines[i]) {
+			gap = append(gap, lines[i])
+			i++
+			continue
+		}
+		if len(gap) > 0 && len(paragraphs) == 1 {
+			separator = "\n" + strings.Join(gap, "\n") + "\n"
+		}
+		gap = nil
+
+		end := i
+		for end < last && !blank(lines[end]) {
+			end++
+		}
+		paragraphs = append(paragraphs, lines[i:end])
+		i = end
+	}
+	return prefix, paragraphs, suffix, separator
+}
+
+// splitRecordStart разбивает строки на записи, начинающиеся со строки, совпавшей с start.
+// Строки до первой такой строки возвращаются отдельно.
+func splitRecordStart(lines []string, start *regexp.Regexp) (prefix []string, records [][]string) {
+	for i, line := range lines {
+		switch {
+		case start.MatchString(line):
+			records = append(records, []string{line})
+		case len(records) == 0:
+			prefix = lines[:i+1]
+		default:
+			records[len(records)-1] = append(records[len(records)-1], line)
+		}
+	}
+	return prefix, records
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortMultiline(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "paragraphs by first line",
+			input:    "[b]\nx=1\n\n[c]\n\n[a]\ny=2\nz=3",
+			opts:     domain.SortOptions{Paragraph: true},
+			expected: "[a]\ny=2\nz=3\n\n[b]\nx=1\n\n[c]",
+		},
+		{
+			name:     "paragraph separator and outer blank lines kept",
+			input:    "\ndn: uid=b\n\n\ndn: uid=a\n  \ndn: uid=c\n",
+			opts:     domain.SortOptions{Paragraph: true},
+			expected: "\ndn: uid=a\n\n\ndn: uid=b\n\n\ndn: uid=c\n",
+		},
+		{
+			name:     "paragraphs by key line field",
+			input:    "host a\nport 443\n\nhost b\nport 80",
+			opts:     domain.SortOptions{Paragraph: true, KeyLine: 2, Keys: []domain.SortKey{{Field: 2, Type: "n"}}},
+			expected: "host b\nport 80\n\nhost a\nport 443",
+		},
+		{
+			name:     "reverse puts records without key line first",
+			input:    "one\n\nb\n2\n\na\n1",
+			opts:     domain.SortOptions{Paragraph: true, KeyLine: 2, Reverse: true},
+			expected: "one\n\nb\n2\n\na\n1",
+		},
+		{
+			name: "stack traces by record start",
+			input: "Started\n" +
+				"2026-01-02 ERROR boom\n\tat a.B(B.java:1)\n\tat a.C(C.java:2)\n" +
+				"2026-01-01 WARN slow\n",
+			opts: domain.SortOptions{RecordStart: `^\d{4}-`},
+			expected: "Started\n" +
+				"2026-01-01 WARN slow\n" +
+				"2026-01-02 ERROR boom\n\tat a.B(B.java:1)\n\tat a.C(C.java:2)\n",
+		},
+		{
+			name:     "record start with field key and unique",
+			input:    "From b 2\nbody\nFrom a 1\nFrom c 1\nbody",
+			opts:     domain.SortOptions{RecordStart: "^From ", Key: true, Field: 3, Unique: true},
+			expected: "From a 1\nFrom b 2\nbody",
+		},
+		{
+			name:     "no record start leaves input",
+			input:    "a\nb",
+			opts:     domain.SortOptions{RecordStart: "^x"},
+			expected: "a\nb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SortMultiline(tt.input, identity, tt.opts)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := Sort("a", domain.SortOptions{RecordStart: "("})
+	require.ErrorIs(t, err, domain.ErrInvalidRecordStart)
+	_, err = Sort("a", domain.SortOptions{RecordStart: "^a", Paragraph: true})
+	require.ErrorIs(t, err, domain.ErrConflictOpts)
+}
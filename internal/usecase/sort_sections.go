@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// defaultSectionRegex распознает заголовки секций по умолчанию (флаг --sections):
+// секции INI ("[core]") и строки комментариев "#" или ";" (.gitignore, CODEOWNERS).
+const defaultSectionRegex = `^\s*(\[[^\]]*\]\s*$|[#;])`
+
+// newSectionRegex компилирует выражение заголовков секций --section-regex
+// или выражение по умолчанию для --sections; без флагов возвращает nil.
+func newSectionRegex(opts domain.SortOptions) (*regexp.Regexp, error) {
+	pattern := opts.SectionRegex
+	if pattern == "" && opts.Sections {
+		pattern = defaultSectionRegex
+	}
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidSectionRegex, err)
+	}
+	return re, nil
+}
+
+// sortSections сортирует ввод по секциям (флаги --section-regex, --sections): строки,
+// совпавшие с выражением, считаются заголовками и остаются на месте, а строки между
+// заголовками (и до первого из них) сортируются независимо с остальными опциями.
+// Пустые строки в начале и конце секции, отделяющие ее от соседних, не перемещаются.
+//
+// Пример:
+//
+//	"[b]\nz=1\ny=2\n\n[a]\nx=3" с --sections → "[b]\ny=2\nz=1\n\n[a]\nx=3"
+func sortSections(input string, opts domain.SortOptions) (string, error) {
+	header, _ := newSectionRegex(opts) // выражение проверяется в Sort
+	opts.SectionRegex, opts.Sections = "", false
+
+	var result, body []string
+	flush := func() error {
+		blank := func(line string) bool { return strings.TrimSpace(line) == "" }
+		first, last := 0, len(body)
+		for first < last && blank(body[first]) {
+			first++
+		}
+		for last > first && blank(body[last-1]) {
+			last--
+		}
+
+		result = append(result, body[:first]...)
+		if first < last {
+			sorted, err := Sort(strings.Join(body[first:last], "\n"), opts)
+			if err != nil {
+				return err
+			}
+			result = append(result, sorted)
+		}
+		result = append(result, body[last:]...)
+		body = nil
+		return nil
+	}
+
+	for _, line := range strings.Split(input, "\n") {
+		if !header.MatchString(line) {
+			body = append(body, line)
+			continue
+		}
+		if err := flush(); err != nil {
+			return "", err
+		}
+		result = append(result, line)
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+	return strings.Join(result, "\n"), nil
+}
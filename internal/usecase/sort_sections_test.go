@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:     "ini sections with custom regex",
+			input:    "[remote]\nurl=b\nfetch=a\n\n[core]\nz=1\nbare=false\n",
+			opts:     domain.SortOptions{SectionRegex: `^\[`},
+			expected: "[remote]\nfetch=a\nurl=b\n\n[core]\nbare=false\nz=1\n",
+		},
+		{
+			name:     "comment headers by default",
+			input:    "/vendor\n# Logs\n*.log\n*.bak\n; old style\n# Build\n/dist\n/bin",
+			opts:     domain.SortOptions{Sections: true},
+			expected: "/vendor\n# Logs\n*.bak\n*.log\n; old style\n# Build\n/bin\n/dist",
+		},
+		{
+			name:     "preamble is sorted too",
+			input:    "b\na\n[s]\nd\nc",
+			opts:     domain.SortOptions{Sections: true},
+			expected: "a\nb\n[s]\nc\nd",
+		},
+		{
+			name:     "options apply to each section",
+			input:    "# ports\n80\n443\n80\n# sizes\n1K\n2\n",
+			opts:     domain.SortOptions{Sections: true, Numeric: true, Reverse: true, Unique: true},
+			expected: "# ports\n443\n80\n# sizes\n1K\n2\n",
+		},
+		{
+			name:     "fields by key spec",
+			input:    "# team\n/api @a 10\n/web @b 2",
+			opts:     domain.SortOptions{Sections: true, KeySpecs: []string{"3:n"}},
+			expected: "# team\n/web @b 2\n/api @a 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Sort(tt.input, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := Sort("a", domain.SortOptions{SectionRegex: "["})
+	require.ErrorIs(t, err, domain.ErrInvalidSectionRegex)
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"strings"
+	"unix_sort_lite/internal/domain"
+)
+
+// Символы рамок ASCII-таблиц и таблиц из символов псевдографики
+const (
+	tableBorderRunes = "+-=:─━═┌┐└┘├┤┬┴┼╔╗╚╝╠╣╦╩╬╞╡╪╟╢╫"
+	tableRuleRunes   = "-=─━═"
+	tableCellRunes   = "|│║"
+)
+
+// textTable — найденная во вводе таблица: имена колонок заголовка и записи тела
+// в строках [bodyStart, bodyEnd). Разделители между записями (в таблицах с линией
+// после каждой строки) остаются на своих местах.
+type textTable struct {
+	names      []string
+	records    []record
+	separators []string
+	bodyStart  int
+	bodyEnd    int
+	end        int // строка после таблицы
+}
+
+// SortTables сортирует строки таблиц во вводе (флаг --table): Markdown-таблиц
+// ("| a | b |" со строкой выравнивания "|---|:--:|") и таблиц с рамкой из "+---+"
+// или символов псевдографики ("┌─┬─┐", "├─┼─┤"). Заголовок и строка выравнивания
+// остаются сверху, сортируются только строки тела; текст вне таблиц не меняется.
+// Строки выводятся без изменений, поэтому выравнивание колонок сохраняется.
+//
+// Ключи задаются номером колонки (-k 2:n), списком --order-by по именам колонок
+// заголовка каждой таблицы или, без ключей, всеми колонками слева направо.
+// В таблицах с многострочными ячейками (строки разделены "+---+") запись — строки
+// между разделителями, значения ячеек объединяются через пробел.
+//
+// Пример:
+//
+//	"| host | ms |\n|---|---:|\n| a | 30 |\n| b | 5 |" с -k 2:n
+//	→ "| host | ms |\n|---|---:|\n| b | 5 |\n| a | 30 |"
+func SortTables(s string, modify func(string) string, opts domain.SortOptions) (string, error) {
+	lines := strings.Split(s, "\n")
+
+	var result []string
+	next := 0
+	for _, table := range findTables(lines) {
+		tableOpts := opts
+		switch {
+		case opts.OrderBy != "":
+			keys, err := parseOrderBy(opts.OrderBy, table.names)
+			if err != nil {
+				return "", err
+			}
+			tableOpts.Keys = keys
+		case opts.Key:
+			tableOpts.Keys = []domain.SortKey{{Field: opts.Field}}
+		case len(opts.Keys) == 0:
+			for i := range table.names {
+				tableOpts.Keys = append(tableOpts.Keys, domain.SortKey{Field: i + 1})
+			}
+		}
+		tableOpts.Key, tableOpts.Field = false, 0
+
+		sortRecords(table.records, modify, tableOpts)
+		records := orderRecords(table.records, modify, tableOpts)
+
+		result = append(result, lines[next:table.bodyStart]...)
+		for i, rec := range records {
+			if i > 0 && len(table.separators) > 0 {
+				result = append(result, table.separators[min(i-1, len(table.separators)-1)])
+			}
+			result = append(result, rec.raw)
+		}
+		next = table.bodyEnd
+	}
+	result = append(result, lines[next:]...)
+	return strings.Join(result, "\n"), nil
+}
+
+// findTables находит таблицы в строках ввода.
+func findTables(lines []string) []textTable {
+	var tables []textTable
+	for i := 0; i < len(lines); i++ {
+		var (
+			table textTable
+			ok    bool
+		)
+		switch {
+		case isTableBorder(lines[i]):
+			table, ok = gridTable(lines, i)
+		case i+1 < len(lines) && isTableRow(lines[i]) && isAlignmentRow(lines[i+1]):
+			table, ok = markdownTable(lines, i), true
+		}
+		if ok {
+			tables = append(tables, table)
+			i = table.end - 1
+		}
+	}
+	return tables
+}
+
+// markdownTable разбирает Markdown-таблицу, начинающуюся с заголовка в строке start.
+func markdownTable(lines []string, start int) textTable {
+	table := textTable{names: tableCells(lines[start]), bodyStart: start + 2}
+	end := table.bodyStart
+	for end < len(lines) && isTableRow(lines[end]) {
+		table.records = append(table.records, record{raw: lines[end], fields: tableCells(lines[end])})
+		end++
+	}
+	table.end, table.bodyEnd = end, end
+	return table
+}
+
+// gridTable разбирает таблицу с рамкой, начинающуюся с верхней границы в строке start.
+// Заголовок отделяется первой двойной границей ("+===+", "╞═══╡") или, если ее нет,
+// второй границей; таблица из одной секции целиком считается телом.
+func gridTable(lines []string, start int) (textTable, bool) {
+	var borders []int
+	end := start
+	for end < len(lines) && (isTableBorder(lines[end]) || isTableRow(lines[end])) {
+		if isTableBorder(lines[end]) {
+			borders = append(borders, end)
+		}
+		end++
+	}
+	if len(borders) < 2 || end-start == len(borders) {
+		return textTable{}, false
+	}
+
+	table := textTable{bodyStart: start + 1, bodyEnd: end, end: end}
+	if last := borders[len(borders)-1]; last == end-1 {
+		table.bodyEnd = last
+		borders = borders[:len(borders)-1]
+	}
+	if len(borders) > 1 {
+		header := borders[1]
+		for _, border := range borders[1:] {
+			if strings.ContainsAny(lines[border], "=═") {
+				header = border
+				break
+			}
+		}
+		table.names = joinCells(lines[start+1 : header])
+		table.bodyStart = header + 1
+	}
+
+	// Если строки тела разделены границами, запись — группа строк между ними,
+	// иначе каждая строка тела — отдельная запись
+	body := lines[table.bodyStart:table.bodyEnd]
+	grouped := false
+	for _, line := range body {
+		grouped = grouped || isTableBorder(line)
+	}
+	var group []string
+	flush := func() {
+		if len(group) > 0 {
+			table.records = append(table.records, record{raw: strings.Join(group, "\n"), fields: joinCells(group)})
+			group = nil
+		}
+	}
+	for _, line := range body {
+		if isTableBorder(line) {
+			flush()
+			table.separators = append(table.separators, line)
+			continue
+		}
+		group = append(group, line)
+		if !grouped {
+			flush()
+		}
+	}
+	flush()
+	return table, true
+}
+
+// joinCells объединяет ячейки строк одной записи по колонкам через пробел.
+func joinCells(lines []string) []string {
+	var fields []string
+	for _, line := range lines {
+		for i, cell := range tableCells(line) {
+			switch {
+			case i >= len(fields):
+				fields = append(fields, cell)
+			case cell != "":
+				fields[i] = strings.TrimSpace(fields[i] + " " + cell)
+			}
+		}
+	}
+	return fields
+}
+
+// tableCells разбивает строку таблицы на ячейки по "|", "│" или "║"; "\|" не разделяет ячейки.
+// Крайние разделители необязательны, значения очищаются от пробелов.
+func tableCells(line string) []string {
+	runes := []rune(strings.TrimSpace(line))
+	if len(runes) == 0 {
+		return nil
+	}
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '|':
+			cell.WriteRune('|')
+			i++
+		case strings.ContainsRune(tableCellRunes, runes[i]):
+			if i > 0 {
+				cells = append(cells, strings.TrimSpace(cell.String()))
+			}
+			cell.Reset()
+		default:
+			cell.WriteRune(runes[i])
+		}
+	}
+	if rest := strings.TrimSpace(cell.String()); rest != "" || !strings.ContainsRune(tableCellRunes, runes[len(runes)-1]) {
+		cells = append(cells, rest)
+	}
+	return cells
+}
+
+// isTableRow сообщает, содержит ли строка разделитель ячеек таблицы.
+func isTableRow(line string) bool {
+	return strings.TrimSpace(line) != "" && strings.ContainsAny(line, tableCellRunes) && !isTableBorder(line)
+}
+
+// isAlignmentRow распознает строку выравнивания Markdown-таблицы: "|---|:--:|", "--|--".
+func isAlignmentRow(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.Contains(trimmed, "|") && strings.Contains(trimmed, "-") &&
+		strings.Trim(trimmed, "|:- \t") == ""
+}
+
+// isTableBorder распознает границу таблицы с рамкой: "+---+---+", "+===+", "├───┼───┤".
+func isTableBorder(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || !strings.ContainsAny(trimmed, tableRuleRunes) {
+		return false
+	}
+	first := []rune(trimmed)[0]
+	if first != '+' && !strings.ContainsRune("┌└├╔╚╠╞╟", first) {
+		return false
+	}
+	return strings.Trim(trimmed, tableBorderRunes+" ") == ""
+}
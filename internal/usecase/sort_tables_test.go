@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"testing"
+	"unix_sort_lite/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableCells(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected []string
+	}{
+		{name: "outer pipes", line: "| host | 30 ms |", expected: []string{"host", "30 ms"}},
+		{name: "no outer pipes", line: "host | 30", expected: []string{"host", "30"}},
+		{name: "empty cell", line: "| | x |", expected: []string{"", "x"}},
+		{name: "escaped pipe", line: `| a \| b | c |`, expected: []string{"a | b", "c"}},
+		{name: "box drawing", line: "│ ёж │ 2 │", expected: []string{"ёж", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, tableCells(tt.line))
+		})
+	}
+}
+
+func TestSortTables(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	markdown := "Hosts:\n\n" +
+		"| host | ms  |\n" +
+		"|------|----:|\n" +
+		"| c    |   5 |\n" +
+		"| a    |  30 |\n" +
+		"| b    | 120 |\n" +
+		"\nEnd."
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     domain.SortOptions
+		expected string
+	}{
+		{
+			name:  "markdown by all columns",
+			input: markdown,
+			opts:  domain.SortOptions{},
+			expected: "Hosts:\n\n| host | ms  |\n|------|----:|\n" +
+				"| a    |  30 |\n| b    | 120 |\n| c    |   5 |\n\nEnd.",
+		},
+		{
+			name:  "markdown by numbered typed column",
+			input: markdown,
+			opts:  domain.SortOptions{Keys: []domain.SortKey{{Field: 2, Type: "n"}}},
+			expected: "Hosts:\n\n| host | ms  |\n|------|----:|\n" +
+				"| c    |   5 |\n| a    |  30 |\n| b    | 120 |\n\nEnd.",
+		},
+		{
+			name:  "markdown by column name descending",
+			input: markdown,
+			opts:  domain.SortOptions{OrderBy: "ms:n DESC"},
+			expected: "Hosts:\n\n| host | ms  |\n|------|----:|\n" +
+				"| b    | 120 |\n| a    |  30 |\n| c    |   5 |\n\nEnd.",
+		},
+		{
+			name: "ascii grid table with reverse",
+			input: "+------+----+\n| name | n  |\n+======+====+\n" +
+				"| a    | 1  |\n| c    | 3  |\n| b    | 2  |\n+------+----+",
+			opts: domain.SortOptions{Key: true, Field: 1, Reverse: true},
+			expected: "+------+----+\n| name | n  |\n+======+====+\n" +
+				"| c    | 3  |\n| b    | 2  |\n| a    | 1  |\n+------+----+",
+		},
+		{
+			name: "multi-line cells between separators",
+			input: "+-----+--------+\n| id  | note   |\n+=====+========+\n" +
+				"| 2   | second |\n|     | line   |\n+-----+--------+\n" +
+				"| 1   | first  |\n+-----+--------+",
+			opts: domain.SortOptions{OrderBy: "id:n"},
+			expected: "+-----+--------+\n| id  | note   |\n+=====+========+\n" +
+				"| 1   | first  |\n+-----+--------+\n" +
+				"| 2   | second |\n|     | line   |\n+-----+--------+",
+		},
+		{
+			name: "box drawing table with unique",
+			input: "┌────┬───┐\n│ k  │ v │\n├────┼───┤\n" +
+				"│ b  │ 1 │\n│ a  │ 2 │\n│ b  │ 3 │\n└────┴───┘",
+			opts: domain.SortOptions{Key: true, Field: 1, Unique: true},
+			expected: "┌────┬───┐\n│ k  │ v │\n├────┼───┤\n" +
+				"│ a  │ 2 │\n│ b  │ 1 │\n└────┴───┘",
+		},
+		{
+			name:     "text without tables unchanged",
+			input:    "b | not a table\na",
+			opts:     domain.SortOptions{},
+			expected: "b | not a table\na",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.opts.Table = true
+			result, err := SortTables(tt.input, identity, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+
+	_, err := SortTables(markdown, identity, domain.SortOptions{Table: true, OrderBy: "latency"})
+	require.ErrorIs(t, err, domain.ErrUnknownColumn)
+}
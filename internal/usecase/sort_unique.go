@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"strings"
+	"unix_sort_lite/internal/domain"
 )
 
 // Unique удаляет дубликаты строк (флаг -u в Unix sort).
@@ -10,11 +11,17 @@ import (
 //
 // Примеры:
 //
-//	"apple\nbanana\napple\ncherry" с field=0 → "apple\nbanana\ncherry"
+//	"apple\nbanana\napple\ncherry" без -k → "apple\nbanana\ncherry"
 //	"apple red\nbanana yellow\napple green" с field=1 → "apple red\nbanana yellow" (уникальность по 1-му полю)
 //	"apple red\nbanana yellow\ngrape red" с field=2 → "apple red\nbanana yellow" (уникальность по 2-му полю)
 //	"apple\nbanana yellow" с field=3 → "apple\nbanana yellow" (строки без поля 3 считаются дубликатами)
-func Unique(s string, field int) string {
+//
+// Поле берется из opts.Field и разделяется так же, как в SortByField: пробельными символами
+// или разделителем -t, с --strip-ansi — после удаления escape-последовательностей.
+// Ключ сравнивается после преобразования modify, как при сортировке: с -b пробелы в конце
+// не учитываются, с --phonetic строки "Smith" и "Smyth" считаются дубликатами.
+func Unique(s string, modify func(string) string, opts domain.SortOptions) string {
+	field := opts.Field
 	lines := strings.Split(s, "\n")
 	// Словарь для отслеживания уже встреченных ключей (строк или полей)
 	dict := make(map[string]bool)
@@ -27,8 +34,8 @@ func Unique(s string, field int) string {
 			key = line
 		} else {
 			// Уникальность по N-му полю (комбинация -uk N)
-			// Разделяем строку на поля по пробелам/табуляциям
-			fields := strings.Fields(line)
+			// Разделяем строку на поля по пробелам/табуляциям или по разделителю -t
+			fields := lineFields(line, opts)
 
 			if len(fields) < field {
 				// Строка не содержит достаточно полей
@@ -38,6 +45,7 @@ func Unique(s string, field int) string {
 			}
 		}
 
+		key = modify(key)
 		if !dict[key] {
 			uniqueRows = append(uniqueRows, line)
 			dict[key] = true
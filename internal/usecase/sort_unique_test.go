@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"testing"
+	"unix_sort_lite/internal/domain"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestUnique(t *testing.T) {
+	identity := func(s string) string { return s }
+
 	tests := []struct {
 		name     string
 		input    string
@@ -120,13 +123,50 @@ func TestUnique(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := Unique(tt.input, tt.field)
+			result := Unique(tt.input, identity, domain.SortOptions{Field: tt.field})
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUniqueWithSeparator(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	tests := []struct {
+		name     string
+		input    string
+		field    int
+		sep      string
+		expected string
+	}{
+		{
+			name:     "unique by csv column",
+			input:    "1,alice\n2,bob\n3,alice",
+			field:    2,
+			sep:      ",",
+			expected: "1,alice\n2,bob",
+		},
+		{
+			name:     "spaces belong to field",
+			input:    "a,x y\nb,x\nc,x y",
+			field:    2,
+			sep:      ",",
+			expected: "a,x y\nb,x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := Unique(tt.input, identity, domain.SortOptions{Field: tt.field, Separator: tt.sep})
 			require.Equal(t, tt.expected, result)
 		})
 	}
 }
 
 func TestUniqueEdgeCases(t *testing.T) {
+	identity := func(s string) string { return s }
+
 	tests := []struct {
 		name     string
 		input    string
@@ -174,8 +214,42 @@ func TestUniqueEdgeCases(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result := Unique(tt.input, tt.field)
+			result := Unique(tt.input, identity, domain.SortOptions{Field: tt.field})
 			require.Equal(t, tt.expected, result)
 		})
 	}
 }
+
+func TestUniqueNormalized(t *testing.T) {
+	normalize, err := normalizeTransform("NFC")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		input    string
+		modify   func(string) string
+		field    int
+		expected string
+	}{
+		{
+			name:     "NFC and NFD names are duplicates",
+			input:    "René\nRene\u0301\nRene",
+			modify:   normalize,
+			expected: "René\nRene",
+		},
+		{
+			name:     "accents ignored in field",
+			input:    "1 Zoë\n2 Zoe\n3 Zoey",
+			modify:   foldAccents,
+			field:    2,
+			expected: "1 Zoë\n3 Zoey",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, Unique(tt.input, tt.modify, domain.SortOptions{Field: tt.field}))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+//go:build linux
+
+package usecase
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileTimes возвращает время доступа и время изменения inode из syscall.Stat_t.
+// Если системная структура недоступна, используется время изменения файла.
+func fileTimes(info os.FileInfo) (time.Time, time.Time) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.ModTime(), info.ModTime()
+	}
+	return time.Unix(st.Atim.Unix()), time.Unix(st.Ctim.Unix())
+}
+
+// fileOwner возвращает uid владельца файла.
+func fileOwner(info os.FileInfo) (uint32, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return st.Uid, true
+}
@@ -0,0 +1,19 @@
+//go:build !linux && !darwin
+
+package usecase
+
+import (
+	"os"
+	"time"
+)
+
+// fileTimes возвращает время изменения файла вместо времени доступа и изменения inode,
+// которые на этой платформе недоступны через os.FileInfo.
+func fileTimes(info os.FileInfo) (time.Time, time.Time) {
+	return info.ModTime(), info.ModTime()
+}
+
+// fileOwner сообщает, что владелец файла на этой платформе недоступен.
+func fileOwner(os.FileInfo) (uint32, bool) {
+	return 0, false
+}